@@ -1,5 +1,9 @@
 package shipping
 
+import (
+	"context"
+)
+
 // This is a more generic form, used primary for output.
 type Estimate struct {
 	Name     string
@@ -23,6 +27,19 @@ type Package struct {
 	Length float64
 }
 
+// A generic postal address. Only the fields a carrier needs for the call at
+// hand are used; for rating that is usually just PostalCode and Country.
+type Address struct {
+	Name       string
+	Company    string
+	Street1    string
+	Street2    string
+	City       string
+	State      string // Two-letter state or province code.
+	PostalCode string
+	Country    string // Two-letter ISO country code. Empty means "US".
+}
+
 type Carrier string
 
 const (
@@ -30,3 +47,15 @@ const (
 	UPS   Carrier = "UPS"
 	USPS  Carrier = "USPS"
 )
+
+// Rater is implemented by every carrier adapter. Service strings are the
+// carrier's own service identifiers, as found in Estimate.Service.
+type Rater interface {
+	Carrier() Carrier
+
+	// Rate prices a single service.
+	Rate(ctx context.Context, service string, from, to Address, p Package) (Estimate, error)
+
+	// Shop prices every service the carrier offers between from and to.
+	Shop(ctx context.Context, from, to Address, p Package) ([]Estimate, error)
+}
@@ -0,0 +1,33 @@
+package shipping
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// Starts a server that answers every request with reply and returns its URL,
+// along with the bodies it received. USPS bodies are form encoded, so the
+// XML form value is recorded for them instead.
+func testServer(t *testing.T, reply string) (string, *[]string) {
+	t.Helper()
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		body := string(data)
+		if values, err := url.ParseQuery(body); err == nil && values.Has("XML") {
+			body = values.Get("XML")
+		}
+		bodies = append(bodies, body)
+		io.WriteString(w, reply)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL, &bodies
+}
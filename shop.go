@@ -0,0 +1,163 @@
+package shipping
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The default per-carrier deadline used by ShopAll when Register is given a
+// zero timeout.
+const DefaultTimeout = 10 * time.Second
+
+type registration struct {
+	rater   Rater
+	timeout time.Duration
+}
+
+// Shopper holds a set of carriers to shop together. The zero value has no
+// carriers and is ready to use.
+type Shopper struct {
+	mu     sync.Mutex
+	raters []registration
+}
+
+// Register adds a carrier to the set consulted by ShopAll. Each call made to
+// the carrier is bounded by timeout.
+func (s *Shopper) Register(r Rater, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.raters = append(s.raters, registration{r, timeout})
+}
+
+// The Shopper used by the package-level Register and ShopAll.
+var defaultShopper Shopper
+
+// Register adds a carrier to the package-wide set consulted by ShopAll.
+func Register(r Rater, timeout time.Duration) {
+	defaultShopper.Register(r, timeout)
+}
+
+// ShopAll shops every carrier added with Register. See Shopper.ShopAll.
+func ShopAll(ctx context.Context, from, to Address, p Package) ([]Estimate, error) {
+	return defaultShopper.ShopAll(ctx, from, to, p)
+}
+
+// CarrierError records why a single carrier did not contribute to ShopAll.
+type CarrierError struct {
+	Carrier Carrier
+	Err     error
+}
+
+func (e *CarrierError) Error() string {
+	return string(e.Carrier) + ": " + e.Err.Error()
+}
+
+func (e *CarrierError) Unwrap() error {
+	return e.Err
+}
+
+// CarrierErrors is returned by ShopAll when one or more carriers failed.
+type CarrierErrors []*CarrierError
+
+func (e CarrierErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "shipping.ShopAll: " + strings.Join(messages, "; ")
+}
+
+func (e CarrierErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+type shopResult struct {
+	carrier   Carrier
+	estimates []Estimate
+	err       error
+}
+
+// ShopAll asks every registered carrier for rates at the same time and
+// returns the combined estimates sorted by price, cheapest first. Carriers
+// that fail or miss their deadline are reported in a CarrierErrors; the
+// estimates from the others are still returned.
+func (s *Shopper) ShopAll(ctx context.Context, from, to Address, p Package) ([]Estimate, error) {
+	s.mu.Lock()
+	raters := append([]registration(nil), s.raters...)
+	s.mu.Unlock()
+
+	results := make(chan shopResult, len(raters))
+	for _, reg := range raters {
+		go func(reg registration) {
+			results <- shopOne(ctx, reg, from, to, p)
+		}(reg)
+	}
+
+	var estimates []Estimate
+	var errs CarrierErrors
+	for range raters {
+		result := <-results
+		if result.err != nil {
+			errs = append(errs, &CarrierError{result.carrier, result.err})
+		}
+		estimates = append(estimates, result.estimates...)
+	}
+
+	// Results arrive in completion order, so ties are broken explicitly to
+	// keep the output the same from call to call.
+	sort.Slice(estimates, func(i, j int) bool {
+		a, b := estimates[i], estimates[j]
+		if a.Price != b.Price {
+			return a.Price < b.Price
+		}
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		return a.Name < b.Name
+	})
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Carrier < errs[j].Carrier
+	})
+
+	if len(errs) > 0 {
+		return estimates, errs
+	}
+	return estimates, nil
+}
+
+// Runs a single carrier under its own deadline. A carrier that ignores its
+// context is abandoned when the deadline passes; its goroutine finishes in
+// the background and the result is discarded.
+func shopOne(ctx context.Context, reg registration, from, to Address, p Package) shopResult {
+	ctx, cancel := context.WithTimeout(ctx, reg.timeout)
+	defer cancel()
+
+	carrier := reg.rater.Carrier()
+	done := make(chan shopResult, 1)
+	go func() {
+		estimates, err := reg.rater.Shop(ctx, from, to, p)
+		done <- shopResult{carrier, estimates, err}
+	}()
+
+	select {
+	case result := <-done:
+		return result
+	case <-ctx.Done():
+		return shopResult{carrier: carrier, err: fmt.Errorf("no response after %v: %w", reg.timeout, ctx.Err())}
+	}
+}
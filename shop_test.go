@@ -0,0 +1,144 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeRater answers Shop with fixed estimates or an error after delay. Unless
+// ignoreContext is set it gives up when its context is done.
+type fakeRater struct {
+	carrier       Carrier
+	estimates     []Estimate
+	err           error
+	delay         time.Duration
+	ignoreContext bool
+}
+
+func (r *fakeRater) Carrier() Carrier {
+	return r.carrier
+}
+
+func (r *fakeRater) Rate(ctx context.Context, service string, from, to Address, p Package) (Estimate, error) {
+	return Estimate{}, errors.New("not implemented")
+}
+
+func (r *fakeRater) Shop(ctx context.Context, from, to Address, p Package) ([]Estimate, error) {
+	if r.ignoreContext {
+		time.Sleep(r.delay)
+	} else {
+		select {
+		case <-time.After(r.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return r.estimates, r.err
+}
+
+func TestShopAllSortsEstimates(t *testing.T) {
+	var s Shopper
+	s.Register(&fakeRater{carrier: USPS, estimates: []Estimate{
+		{Name: "Priority Mail", Provider: USPS, Service: "PRIORITY", Price: 9.45},
+		{Name: "First-Class", Provider: USPS, Service: "FIRST CLASS", Price: 5.20},
+	}, delay: 20 * time.Millisecond}, time.Second)
+	s.Register(&fakeRater{carrier: UPS, estimates: []Estimate{
+		{Name: "Ground", Provider: UPS, Service: "03", Price: 9.45},
+		{Name: "2nd Day Air", Provider: UPS, Service: "02", Price: 25.10},
+	}}, time.Second)
+
+	estimates, err := s.ShopAll(context.Background(), Address{}, Address{}, Package{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Equal prices are ordered by provider, then service.
+	want := []string{"FIRST CLASS", "03", "PRIORITY", "02"}
+	if len(estimates) != len(want) {
+		t.Fatalf("got %d estimates, want %d", len(estimates), len(want))
+	}
+	for i, service := range want {
+		if estimates[i].Service != service {
+			t.Errorf("estimate %d is %s, want %s", i, estimates[i].Service, service)
+		}
+	}
+}
+
+func TestShopAllCarrierDeadline(t *testing.T) {
+	var s Shopper
+	s.Register(&fakeRater{carrier: USPS, estimates: []Estimate{{Provider: USPS, Service: "PRIORITY", Price: 9.45}}}, time.Second)
+	s.Register(&fakeRater{carrier: UPS, delay: time.Minute}, 50*time.Millisecond)
+	s.Register(&fakeRater{carrier: FedEx, delay: time.Second, ignoreContext: true}, 50*time.Millisecond)
+
+	start := time.Now()
+	estimates, err := s.ShopAll(context.Background(), Address{}, Address{}, Package{})
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("ShopAll took %v, want it to stop at the 50ms carrier deadline", elapsed)
+	}
+
+	if len(estimates) != 1 || estimates[0].Provider != USPS {
+		t.Errorf("got %+v, want the USPS estimate alone", estimates)
+	}
+
+	var errs CarrierErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("got %v, want CarrierErrors", err)
+	}
+	if len(errs) != 2 || errs[0].Carrier != FedEx || errs[1].Carrier != UPS {
+		t.Fatalf("got %v, want FedEx and UPS errors in that order", errs)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want it to wrap context.DeadlineExceeded", err)
+	}
+}
+
+func TestShopAllCarrierErrors(t *testing.T) {
+	down := errors.New("service unavailable")
+
+	var s Shopper
+	s.Register(&fakeRater{carrier: USPS, err: down}, time.Second)
+	s.Register(&fakeRater{carrier: UPS, err: down, delay: 10 * time.Millisecond}, time.Second)
+	s.Register(&fakeRater{carrier: FedEx, err: down, delay: 20 * time.Millisecond}, time.Second)
+
+	estimates, err := s.ShopAll(context.Background(), Address{}, Address{}, Package{})
+	if len(estimates) != 0 {
+		t.Errorf("got %+v, want no estimates", estimates)
+	}
+
+	var errs CarrierErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("got %v, want CarrierErrors", err)
+	}
+
+	want := []Carrier{FedEx, UPS, USPS}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d", len(errs), len(want))
+	}
+	for i, carrier := range want {
+		if errs[i].Carrier != carrier {
+			t.Errorf("error %d is for %s, want %s", i, errs[i].Carrier, carrier)
+		}
+	}
+	if !errors.Is(err, down) {
+		t.Errorf("got %v, want it to wrap the carrier error", err)
+	}
+}
+
+func TestShopAllNoCarriers(t *testing.T) {
+	var s Shopper
+	estimates, err := s.ShopAll(context.Background(), Address{}, Address{}, Package{})
+	if len(estimates) != 0 || err != nil {
+		t.Errorf("got %v, %v; want nothing", estimates, err)
+	}
+}
+
+func TestRegisterDefaultTimeout(t *testing.T) {
+	var s Shopper
+	s.Register(&fakeRater{carrier: USPS}, 0)
+
+	if got := s.raters[0].timeout; got != DefaultTimeout {
+		t.Errorf("timeout = %v, want DefaultTimeout", got)
+	}
+}
@@ -0,0 +1,115 @@
+package shipping
+
+import (
+	"context"
+	"math"
+
+	"github.com/functionary/shipping/ups"
+)
+
+// UPSRater adapts the ups package to the Rater interface.
+type UPSRater struct {
+	Client  *ups.Client
+	Shipper ups.ShipperType
+
+	// PickupType defaults to ups.PickupTypeDaily.
+	PickupType ups.PickupTypeCode
+}
+
+func (r *UPSRater) Carrier() Carrier {
+	return UPS
+}
+
+func (r *UPSRater) Rate(ctx context.Context, service string, from, to Address, p Package) (Estimate, error) {
+	e, err := r.Client.Rate(ctx, r.request(ups.ServiceCode(service), from, to, p))
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	return fromUPS(e), nil
+}
+
+func (r *UPSRater) Shop(ctx context.Context, from, to Address, p Package) ([]Estimate, error) {
+	list, err := r.Client.Shop(ctx, r.request("", from, to, p))
+	if err != nil {
+		return nil, err
+	}
+
+	estimates := make([]Estimate, 0, len(list))
+	for _, e := range list {
+		estimates = append(estimates, fromUPS(e))
+	}
+
+	return estimates, nil
+}
+
+func (r *UPSRater) request(service ups.ServiceCode, from, to Address, p Package) *ups.RatingServiceSelectionRequest {
+	var req ups.RatingServiceSelectionRequest
+	req.PickupType.Code = r.PickupType
+	if req.PickupType.Code == "" {
+		req.PickupType.Code = ups.PickupTypeDaily
+	}
+
+	req.Shipment.Shipper = r.Shipper
+	req.Shipment.ShipFrom.CompanyName = from.Company
+	req.Shipment.ShipFrom.Address = toUPSAddress(from)
+	req.Shipment.ShipTo.CompanyName = to.Company
+	req.Shipment.ShipTo.Address = toUPSAddress(to)
+	req.Shipment.Service.Code = service
+	req.Shipment.Packages = []ups.PackageType{toUPSPackage(p)}
+
+	return &req
+}
+
+func toUPSAddress(a Address) ups.AddressType {
+	country := a.Country
+	if country == "" {
+		country = "US"
+	}
+
+	return ups.AddressType{
+		AddressLine1:      a.Street1,
+		AddressLine2:      a.Street2,
+		City:              a.City,
+		StateProvinceCode: a.State,
+		PostalCode:        a.PostalCode,
+		CountryCode:       country,
+	}
+}
+
+// UPS wants pounds; the generic Package is in ounces.
+func toUPSPackage(p Package) ups.PackageType {
+	var pkg ups.PackageType
+	pkg.PackagingType.Code = ups.PackagingTypePackage
+	pkg.PackageWeight.UnitOfMeasurement.Code = "LBS"
+	pkg.PackageWeight.Weight = upsPounds(p.Weight)
+
+	if p.Width > 0 || p.Height > 0 || p.Length > 0 {
+		pkg.Dimensions = []ups.DimensionsType{{
+			Length: p.Length,
+			Width:  p.Width,
+			Height: p.Height,
+		}}
+		pkg.Dimensions[0].UnitOfMeasurement.Code = "IN"
+	}
+
+	return pkg
+}
+
+// Converts ounces to pounds at the 0.1lb precision UPS accepts. Weights are
+// rounded up, and never go below the 0.1lb minimum.
+func upsPounds(ounces float64) float64 {
+	// The small allowance keeps exact tenths, e.g. 8oz, from rounding up
+	// through floating point error.
+	pounds := math.Ceil(ounces/16*10-1e-9) / 10
+	return math.Max(pounds, 0.1)
+}
+
+func fromUPS(e ups.Estimate) Estimate {
+	return Estimate{
+		Name:     e.Description,
+		Provider: UPS,
+		Service:  string(e.Service),
+		Price:    e.Cost,
+	}
+}
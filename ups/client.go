@@ -0,0 +1,111 @@
+package ups
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// UPS XPCI endpoints. The tool name, e.g. "Rate", is appended to these.
+const (
+	ProductionURL = "https://onlinetools.ups.com/ups.app/xml/"
+	TestingURL    = "https://wwwcie.ups.com/ups.app/xml/"
+)
+
+// Client talks to the UPS XML (XPCI) tools on behalf of a single account.
+type Client struct {
+	Access AccessRequest
+
+	// BaseURL defaults to ProductionURL.
+	BaseURL string
+
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Returns a Client for the production endpoint.
+func NewClient(accessLicenseNumber, userId, password string) *Client {
+	return &Client{
+		Access: AccessRequest{
+			AccessLicenseNumber: accessLicenseNumber,
+			UserId:              userId,
+			Password:            password,
+		},
+		BaseURL: ProductionURL,
+	}
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+	return ProductionURL
+}
+
+// Posts the AccessRequest followed by request to the named tool and
+// unmarshals the reply into response. A failed reply is returned as an
+// *Error. XPCI expects the two XML documents
+// back to back in one body, each with its own declaration.
+func (c *Client) call(ctx context.Context, caller, tool string, request, response interface{}) error {
+	access := c.Access
+	access.Lang = lang
+
+	buf := new(bytes.Buffer)
+	for _, doc := range []interface{}{&access, request} {
+		buf.WriteString(xml.Header)
+		if err := xml.NewEncoder(buf).Encode(doc); err != nil {
+			return fmt.Errorf("%s: XML marshalling failed:\n%w", caller, err)
+		}
+	}
+
+	target := strings.TrimSuffix(c.baseURL(), "/") + "/" + tool
+	req, err := http.NewRequestWithContext(ctx, "POST", target, buf)
+	if err != nil {
+		return fmt.Errorf("%s: Unable to build request:\n%w", caller, err)
+	}
+	req.Header.Set("Content-Type", "application/xml")
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: Data send failed:\n%w", caller, err)
+	}
+	defer resp.Body.Close()
+
+	rawxml, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: Error while reading response:\n%w", caller, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: Unexpected HTTP status %s", caller, resp.Status)
+	}
+
+	// Every XPCI reply carries a <Response> block; failures are reported
+	// there rather than through the HTTP status.
+	var status struct {
+		Response ResponseType
+	}
+	if err := xml.Unmarshal(rawxml, &status); err != nil {
+		return fmt.Errorf("%s: XML unmarshalling failed:\n%w", caller, err)
+	}
+	if err := status.Response.err(); err != nil {
+		return err
+	}
+
+	if err := xml.Unmarshal(rawxml, response); err != nil {
+		return fmt.Errorf("%s: XML unmarshalling failed:\n%w", caller, err)
+	}
+
+	return nil
+}
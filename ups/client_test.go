@@ -0,0 +1,124 @@
+package ups
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// Compares got with testdata/name, or rewrites the file when -update is set.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s mismatch:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// A recorded XPCI request.
+type sent struct {
+	Path string
+	Body []byte
+}
+
+// Starts a server that answers requests with replies in turn, repeating the
+// last one, and returns a Client pointed at it along with the requests it
+// received.
+func testClient(t *testing.T, replies ...string) (*Client, *[]sent) {
+	t.Helper()
+
+	var requests []sent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		requests = append(requests, sent{Path: r.URL.Path, Body: body})
+		io.WriteString(w, replies[min(len(requests), len(replies))-1])
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient("LICENSE", "USER", "PASSWORD")
+	c.BaseURL = server.URL
+	return c, &requests
+}
+
+// Checks the parts of an XPCI body every tool relies on: an AccessRequest
+// and the request as two documents with their own declarations, xml:lang on
+// both roots, and the XPCI version on the request.
+func checkEnvelope(t *testing.T, body []byte, root string) {
+	t.Helper()
+
+	docs := strings.Split(string(body), xml.Header)
+	if len(docs) != 3 || docs[0] != "" {
+		t.Fatalf("want two documents each starting with an XML declaration, got:\n%s", body)
+	}
+
+	if !strings.HasPrefix(docs[1], `<AccessRequest xml:lang="en-US">`) {
+		t.Errorf("AccessRequest root is wrong:\n%s", docs[1])
+	}
+	if !strings.HasPrefix(docs[2], "<"+root+` xml:lang="en-US">`) {
+		t.Errorf("%s root is wrong:\n%s", root, docs[2])
+	}
+	if !strings.Contains(docs[2], "<XpciVersion>"+XpciVersion+"</XpciVersion>") {
+		t.Errorf("XpciVersion missing from %s:\n%s", root, docs[2])
+	}
+}
+
+func TestCallConcatenatesAccessRequest(t *testing.T) {
+	c, requests := testClient(t, `<VoidShipmentResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response></VoidShipmentResponse>`)
+
+	request := &VoidShipmentRequest{Lang: lang, ShipmentIdentificationNumber: "1Z12345E0390817264"}
+	request.Request.TransactionReference.XpciVersion = XpciVersion
+	request.Request.RequestAction = "1"
+
+	var response VoidShipmentResponse
+	if err := c.call(context.Background(), "ups.Void", "Void", request, &response); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(*requests))
+	}
+	r := (*requests)[0]
+	if r.Path != "/Void" {
+		t.Errorf("posted to %s, want /Void", r.Path)
+	}
+	checkEnvelope(t, r.Body, "VoidShipmentRequest")
+	golden(t, "call.xml", r.Body)
+}
+
+// The caller's AccessRequest is copied, so call must not set Lang on it.
+func TestCallLeavesAccessUnchanged(t *testing.T) {
+	c, _ := testClient(t, `<VoidShipmentResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response></VoidShipmentResponse>`)
+
+	var response VoidShipmentResponse
+	if err := c.call(context.Background(), "ups.Void", "Void", &VoidShipmentRequest{}, &response); err != nil {
+		t.Fatal(err)
+	}
+	if c.Access.Lang != "" {
+		t.Errorf("Access.Lang = %q, want it left empty", c.Access.Lang)
+	}
+}
@@ -3,10 +3,10 @@ package ups
 import ()
 
 /*
-
 Package Type
 
 If no container is specified, RAVE assumes UPS Package, i.e., type 02.
+
 	01 = UPS Letter,
 	02 = Customer Supplied Package,
 	03 = Tube,
@@ -42,7 +42,6 @@ Packaging type must be valid for all the following:
 
 UPS will not accept raw wood pallets and please refer the UPS packaging
 guidelines for pallets on UPS.com.
-
 */
 type PackagingTypeCode string
 
@@ -80,12 +79,14 @@ var packageTypeNames map[PackagingTypeCode]string = map[PackagingTypeCode]string
 PICKUP TYPE:
 Default value is 01.
 Valid values are:
+
 	01 = Daily Pickup,
 	03 = Customer Counter,
 	06 = One Time Pickup,
 	07 = On Call Air,
 	19 = Letter Center,
 	20 = Air Service Center.
+
 Refer to the Rate Chart table in Appendix C for rate type based on Pickup Type
 and Customer Classification Code.
 */
@@ -174,8 +175,33 @@ var serviceNames map[ServiceCode]string = map[ServiceCode]string{
 	ServiceWorldwideExpedited:   "Worldwide Expedited",
 	ServiceIntlSaver:            "International Saver"}
 
+// Returns the display name of the service, or the raw code if it is unknown.
+func (c ServiceCode) String() string {
+	if name, ok := serviceNames[c]; ok {
+		return name
+	}
+	return string(c)
+}
+
+/*
+LABEL IMAGE FORMAT:
+
+	GIF = GIF image, wrapped in HTML for browser printing,
+	EPL = Eltron thermal printers,
+	ZPL = Zebra thermal printers,
+	SPL = SATO thermal printers,
+	STARPL = Star thermal printers.
+*/
 type LabelImageFormatCode string
 
+const (
+	LabelImageGIF    LabelImageFormatCode = "GIF"
+	LabelImageEPL    LabelImageFormatCode = "EPL"
+	LabelImageZPL    LabelImageFormatCode = "ZPL"
+	LabelImageSPL    LabelImageFormatCode = "SPL"
+	LabelImageSTARPL LabelImageFormatCode = "STARPL"
+)
+
 type UnitOfMeasurementCode string
 
 type CustomerClassificationCode string
@@ -0,0 +1,84 @@
+package ups
+
+import (
+	"strconv"
+	"strings"
+)
+
+type ErrorSeverity string
+
+const (
+	// The request cannot succeed as sent.
+	SeverityHard ErrorSeverity = "Hard"
+
+	// The request may succeed if retried after MinimumRetrySeconds.
+	SeverityTransient ErrorSeverity = "Transient"
+
+	// The request succeeded, but UPS has something to say about it.
+	SeverityWarning ErrorSeverity = "Warning"
+)
+
+// Error is an <Error> block from the <Response> of any UPS reply.
+type Error struct {
+	Severity            ErrorSeverity `xml:"ErrorSeverity"`
+	Code                string        `xml:"ErrorCode"`
+	Description         string        `xml:"ErrorDescription"`
+	MinimumRetrySeconds int
+	Locations           []ErrorLocation `xml:"ErrorLocation"`
+}
+
+// ErrorLocation names the request element (and attribute) UPS objected to.
+type ErrorLocation struct {
+	ElementName   string `xml:"ErrorLocationElementName"`
+	AttributeName string `xml:"ErrorLocationAttributeName"`
+}
+
+func (e *Error) Error() string {
+	msg := "ups: " + string(e.Severity) + " error " + e.Code + ": " + e.Description
+	if len(e.Locations) > 0 {
+		names := make([]string, len(e.Locations))
+		for i, l := range e.Locations {
+			names[i] = l.ElementName
+			if l.AttributeName != "" {
+				names[i] += "@" + l.AttributeName
+			}
+		}
+		msg += " (at " + strings.Join(names, ", ") + ")"
+	}
+	if e.Severity == SeverityTransient && e.MinimumRetrySeconds > 0 {
+		msg += "; retry after " + strconv.Itoa(e.MinimumRetrySeconds) + "s"
+	}
+	return msg
+}
+
+// Temporary reports whether retrying the request may succeed.
+func (e *Error) Temporary() bool {
+	return e.Severity == SeverityTransient
+}
+
+// Returns the failure described by a <Response> block, or nil when the
+// request succeeded. UPS signals failure with a ResponseStatusCode of 0.
+func (r *ResponseType) err() error {
+	if r.ResponseStatusCode == 1 {
+		return nil
+	}
+
+	for i := range r.Error {
+		if r.Error[i].Severity != SeverityWarning {
+			return &r.Error[i]
+		}
+	}
+
+	return &Error{Severity: SeverityHard, Description: r.ResponseStatusDescription}
+}
+
+// Returns the descriptions of the Warning errors in a <Response> block.
+func (r *ResponseType) warnings() []string {
+	var warnings []string
+	for _, e := range r.Error {
+		if e.Severity == SeverityWarning {
+			warnings = append(warnings, e.Description)
+		}
+	}
+	return warnings
+}
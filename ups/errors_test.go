@@ -0,0 +1,93 @@
+package ups
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCallReturnsResponseError(t *testing.T) {
+	c, _ := testClient(t, `<VoidShipmentResponse><Response><ResponseStatusCode>0</ResponseStatusCode>`+
+		`<Error><ErrorSeverity>Hard</ErrorSeverity><ErrorCode>250003</ErrorCode><ErrorDescription>Invalid Access License number</ErrorDescription></Error>`+
+		`</Response></VoidShipmentResponse>`)
+
+	var response VoidShipmentResponse
+	err := c.call(context.Background(), "ups.Void", "Void", &VoidShipmentRequest{}, &response)
+
+	var upsErr *Error
+	if !errors.As(err, &upsErr) {
+		t.Fatalf("got %v, want an *Error", err)
+	}
+	if upsErr.Code != "250003" || upsErr.Temporary() {
+		t.Errorf("got %+v, want a hard 250003 error", upsErr)
+	}
+	if want := "ups: Hard error 250003: Invalid Access License number"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestTransientError(t *testing.T) {
+	c, _ := testClient(t, `<RatingServiceSelectionResponse><Response><ResponseStatusCode>0</ResponseStatusCode>`+
+		`<Error><ErrorSeverity>Warning</ErrorSeverity><ErrorCode>110971</ErrorCode><ErrorDescription>Rates may differ</ErrorDescription></Error>`+
+		`<Error><ErrorSeverity>Transient</ErrorSeverity><ErrorCode>190001</ErrorCode><ErrorDescription>The system is temporarily unavailable</ErrorDescription><MinimumRetrySeconds>30</MinimumRetrySeconds>`+
+		`<ErrorLocation><ErrorLocationElementName>Shipment</ErrorLocationElementName><ErrorLocationAttributeName>lang</ErrorLocationAttributeName></ErrorLocation></Error>`+
+		`</Response></RatingServiceSelectionResponse>`)
+
+	_, err := c.Shop(context.Background(), &RatingServiceSelectionRequest{Shipment: testShipment()})
+
+	// The warning is skipped in favour of the error that failed the request.
+	var upsErr *Error
+	if !errors.As(err, &upsErr) {
+		t.Fatalf("got %v, want an *Error", err)
+	}
+	if !upsErr.Temporary() || upsErr.MinimumRetrySeconds != 30 {
+		t.Errorf("got %+v, want a temporary error with a 30s retry", upsErr)
+	}
+	if want := "ups: Transient error 190001: The system is temporarily unavailable (at Shipment@lang); retry after 30s"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestFailureWithoutError(t *testing.T) {
+	c, _ := testClient(t, `<RatingServiceSelectionResponse><Response><ResponseStatusCode>0</ResponseStatusCode>`+
+		`<ResponseStatusDescription>Failure</ResponseStatusDescription></Response></RatingServiceSelectionResponse>`)
+
+	_, err := c.Shop(context.Background(), &RatingServiceSelectionRequest{Shipment: testShipment()})
+
+	var upsErr *Error
+	if !errors.As(err, &upsErr) {
+		t.Fatalf("got %v, want an *Error", err)
+	}
+	if upsErr.Severity != SeverityHard || upsErr.Description != "Failure" {
+		t.Errorf("got %+v, want a hard error described as Failure", upsErr)
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	c, _ := testClient(t, `<RatingServiceSelectionResponse><Response><ResponseStatusCode>1</ResponseStatusCode>`+
+		`<Error><ErrorSeverity>Warning</ErrorSeverity><ErrorCode>110971</ErrorCode><ErrorDescription>Your invoice may vary from the displayed reference rates</ErrorDescription></Error>`+
+		`</Response>`+
+		`<RatedShipment><Service><Code>03</Code></Service><RatedShipmentWarning>Ship To Address Classification is changed from Commercial to Residential</RatedShipmentWarning>`+
+		`<TotalCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>12.34</MonetaryValue></TotalCharges></RatedShipment>`+
+		`<RatedShipment><Service><Code>02</Code></Service><TotalCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>25.10</MonetaryValue></TotalCharges></RatedShipment>`+
+		`</RatingServiceSelectionResponse>`)
+
+	estimates, err := c.Shop(context.Background(), &RatingServiceSelectionRequest{Shipment: testShipment()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{
+		{"Ship To Address Classification is changed from Commercial to Residential", "Your invoice may vary from the displayed reference rates"},
+		{"Your invoice may vary from the displayed reference rates"},
+	}
+	if len(estimates) != len(want) {
+		t.Fatalf("got %d estimates, want %d", len(estimates), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(estimates[i].Warnings, want[i]) {
+			t.Errorf("estimate %d warnings = %q, want %q", i, estimates[i].Warnings, want[i])
+		}
+	}
+}
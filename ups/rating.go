@@ -0,0 +1,79 @@
+package ups
+
+import (
+	"context"
+	"errors"
+)
+
+type Estimate struct {
+	Service     ServiceCode
+	Description string // Display name of the service.
+	Cost        float64
+	Currency    string
+
+	// Either may be empty when UPS does not guarantee the service.
+	GuaranteedDaysToDelivery string
+	ScheduledDeliveryTime    string
+
+	// Warnings holds the RatedShipmentWarning messages for this service and
+	// any Warning errors UPS attached to the response as a whole.
+	Warnings []string
+}
+
+// Rate prices the single service in req.Shipment.Service.Code.
+func (c *Client) Rate(ctx context.Context, req *RatingServiceSelectionRequest) (Estimate, error) {
+	var estimate Estimate
+
+	if req.Shipment.Service.Code == "" {
+		return estimate, errors.New("ups.Rate: Shipment.Service.Code is required")
+	}
+
+	estimates, err := c.rate(ctx, "ups.Rate", "Rate", req)
+	if err != nil {
+		return estimate, err
+	}
+
+	if len(estimates) == 0 {
+		return estimate, errors.New("ups.Rate: No rate returned for service " + string(req.Shipment.Service.Code))
+	}
+
+	return estimates[0], nil
+}
+
+// Shop prices every service UPS offers for the shipment. The service code in
+// req is ignored.
+func (c *Client) Shop(ctx context.Context, req *RatingServiceSelectionRequest) ([]Estimate, error) {
+	return c.rate(ctx, "ups.Shop", "Shop", req)
+}
+
+// This does the actual processing of the UPS Rate Request. Rate() and Shop()
+// are both front-ends to this function.
+func (c *Client) rate(ctx context.Context, caller, option string, req *RatingServiceSelectionRequest) ([]Estimate, error) {
+	request := *req
+	request.Lang = lang
+	request.Request.TransactionReference.XpciVersion = XpciVersion
+	request.Request.RequestAction = "Rate"
+	request.Request.RequestOption = option
+
+	var response RatingServiceSelectionResponse
+	if err := c.call(ctx, caller, "Rate", &request, &response); err != nil {
+		return nil, err
+	}
+
+	warnings := response.Response.warnings()
+
+	estimates := make([]Estimate, 0, len(response.RatedShipment))
+	for _, value := range response.RatedShipment {
+		estimates = append(estimates, Estimate{
+			Service:                  value.Service.Code,
+			Description:              value.Service.Code.String(),
+			Cost:                     value.TotalCharges.MonetaryValue,
+			Currency:                 value.TotalCharges.CurrencyCode,
+			GuaranteedDaysToDelivery: value.GuaranteedDaysToDelivery,
+			ScheduledDeliveryTime:    value.ScheduledDeliveryTime,
+			Warnings:                 append(append([]string(nil), value.RatedShipmentWarning...), warnings...),
+		})
+	}
+
+	return estimates, nil
+}
@@ -0,0 +1,140 @@
+package ups
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const rateReply = `<RatingServiceSelectionResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response>` +
+	`<RatedShipment><Service><Code>03</Code></Service><TotalCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>12.34</MonetaryValue></TotalCharges></RatedShipment>` +
+	`</RatingServiceSelectionResponse>`
+
+// A residential delivery of one package that needs additional handling.
+// The other Indicator fields are left false.
+func testShipment() ShipmentType {
+	var s ShipmentType
+
+	s.Shipper = ShipperType{
+		Name:          "Shipper",
+		ShipperNumber: "A12345",
+		Address:       AddressType{AddressLine1: "1 Main St", City: "Timonium", StateProvinceCode: "MD", PostalCode: "21093", CountryCode: "US"},
+	}
+	s.ShipFrom = ShipFromType{CompanyName: "Shipper", Address: s.Shipper.Address}
+	s.ShipTo = ShipToType{
+		CompanyName: "Customer",
+		Address:     AddressType{AddressLine1: "2 Elm St", City: "Atlanta", StateProvinceCode: "GA", PostalCode: "30328", CountryCode: "US", ResidentialAddressIndicator: true},
+	}
+	s.Service.Code = ServiceUSGround
+
+	var p PackageType
+	p.PackagingType.Code = PackagingTypePackage
+	p.Dimensions = []DimensionsType{{UnitOfMeasurement: UnitOfMeasurementType{Code: "IN"}, Length: 20, Width: 10, Height: 8}}
+	p.PackageWeight.UnitOfMeasurement.Code = "LBS"
+	p.PackageWeight.Weight = 12.5
+	p.AdditionalHandling = true
+	s.Packages = []PackageType{p}
+
+	return s
+}
+
+func TestRatingRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		rate func(c *Client, req *RatingServiceSelectionRequest) ([]Estimate, error)
+	}{
+		{"rate", func(c *Client, req *RatingServiceSelectionRequest) ([]Estimate, error) {
+			e, err := c.Rate(context.Background(), req)
+			return []Estimate{e}, err
+		}},
+		{"shop", func(c *Client, req *RatingServiceSelectionRequest) ([]Estimate, error) {
+			return c.Shop(context.Background(), req)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, requests := testClient(t, rateReply)
+
+			req := &RatingServiceSelectionRequest{Shipment: testShipment()}
+			req.PickupType.Code = PickupTypeDaily
+			estimates, err := tt.rate(c, req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := []Estimate{{Service: ServiceUSGround, Description: "Ground", Cost: 12.34, Currency: "USD"}}
+			if !reflect.DeepEqual(estimates, want) {
+				t.Errorf("got %+v, want %+v", estimates, want)
+			}
+
+			body := (*requests)[0].Body
+			checkEnvelope(t, body, "RatingServiceSelectionRequest")
+			checkIndicators(t, string(body))
+			golden(t, tt.name+".xml", body)
+		})
+	}
+}
+
+func TestShopEstimates(t *testing.T) {
+	c, _ := testClient(t, `<RatingServiceSelectionResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response>`+
+		`<RatedShipment><Service><Code>03</Code></Service><TotalCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>12.34</MonetaryValue></TotalCharges></RatedShipment>`+
+		`<RatedShipment><Service><Code>01</Code></Service><GuaranteedDaysToDelivery>1</GuaranteedDaysToDelivery><ScheduledDeliveryTime>10:30 A.M.</ScheduledDeliveryTime><TotalCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>61.20</MonetaryValue></TotalCharges></RatedShipment>`+
+		`<RatedShipment><Service><Code>96</Code></Service><TotalCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>140.00</MonetaryValue></TotalCharges></RatedShipment>`+
+		`</RatingServiceSelectionResponse>`)
+
+	estimates, err := c.Shop(context.Background(), &RatingServiceSelectionRequest{Shipment: testShipment()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Estimate{
+		{Service: ServiceUSGround, Description: "Ground", Cost: 12.34, Currency: "USD"},
+		{Service: ServiceUSNextDayAir, Description: "Next Day Air", Cost: 61.20, Currency: "USD", GuaranteedDaysToDelivery: "1", ScheduledDeliveryTime: "10:30 A.M."},
+		// Codes without a known name are described by the code itself.
+		{Service: "96", Description: "96", Cost: 140, Currency: "USD"},
+	}
+	if !reflect.DeepEqual(estimates, want) {
+		t.Errorf("got %+v\nwant %+v", estimates, want)
+	}
+}
+
+func TestRateNoRate(t *testing.T) {
+	c, _ := testClient(t, `<RatingServiceSelectionResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response></RatingServiceSelectionResponse>`)
+
+	_, err := c.Rate(context.Background(), &RatingServiceSelectionRequest{Shipment: testShipment()})
+	if err == nil || !strings.Contains(err.Error(), "No rate returned for service 03") {
+		t.Errorf("got %v, want a no rate error", err)
+	}
+}
+
+func TestRateServiceRequired(t *testing.T) {
+	c, requests := testClient(t, rateReply)
+
+	req := &RatingServiceSelectionRequest{Shipment: testShipment()}
+	req.Shipment.Service.Code = ""
+	if _, err := c.Rate(context.Background(), req); err == nil {
+		t.Error("got no error without a service code")
+	}
+	if len(*requests) != 0 {
+		t.Errorf("sent %d requests, want none", len(*requests))
+	}
+}
+
+// Checks that the true Indicators of testShipment render as empty elements
+// and the false ones are left out.
+func checkIndicators(t *testing.T, body string) {
+	t.Helper()
+
+	for _, name := range []string{"ResidentialAddressIndicator", "AdditionalHandling"} {
+		if n := strings.Count(body, "<"+name+"></"+name+">"); n != 1 {
+			t.Errorf("<%s> rendered %d times, want once as an empty element", name, n)
+		}
+	}
+	for _, name := range []string{"DocumentsOnly", "LargePackageIndicator", "ItemizedChargesRequestedIndicator"} {
+		if strings.Contains(body, "<"+name) {
+			t.Errorf("<%s> rendered for a false Indicator", name)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package ups
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrNotApproved is returned by Ship when the approve function rejects the
+// quoted charges. Nothing has been bought at that point.
+var ErrNotApproved = errors.New("ups.Ship: Quoted charges were not approved")
+
+// Quote is what UPS will charge for a confirmed, not yet accepted, shipment.
+type Quote struct {
+	ShipmentIdentificationNumber string
+
+	Transportation float64
+	ServiceOptions float64
+	Total          float64
+	Currency       string
+
+	// NegotiatedTotal is zero unless the account has negotiated rates.
+	NegotiatedTotal float64
+
+	Warnings []string
+}
+
+type ShippedPackage struct {
+	TrackingNumber string
+	LabelFormat    LabelImageFormatCode
+	Label          []byte
+}
+
+type Shipment struct {
+	IdentificationNumber string
+	Total                float64
+	Currency             string
+	Packages             []ShippedPackage
+	Warnings             []string
+}
+
+// Ship buys labels in the two steps UPS requires. The shipment is confirmed,
+// approve is shown the quoted charges, and only if it returns true is the
+// shipment accepted and paid for. A nil approve accepts any quote.
+//
+// req.Shipment must carry PaymentInformation. The label specification
+// defaults to GIF images.
+func (c *Client) Ship(ctx context.Context, req *ShipmentConfirmRequest, approve func(Quote) bool) (*Shipment, error) {
+	confirmed, err := c.ConfirmShipment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if approve != nil && !approve(confirmed.quote()) {
+		return nil, ErrNotApproved
+	}
+
+	accepted, err := c.AcceptShipment(ctx, confirmed.ShipmentDigest)
+	if err != nil {
+		return nil, err
+	}
+
+	results := accepted.ShipmentResults
+	shipment := &Shipment{
+		IdentificationNumber: results.ShipmentIdentificationNumber,
+		Total:                results.ShipmentCharges.TotalCharges.MonetaryValue,
+		Currency:             results.ShipmentCharges.TotalCharges.CurrencyCode,
+		Warnings:             accepted.Response.warnings(),
+	}
+
+	for _, p := range results.PackageResults {
+		label, err := base64.StdEncoding.DecodeString(p.LabelImage.GraphicImage)
+		if err != nil {
+			return shipment, fmt.Errorf("ups.Ship: Unable to decode label for %s:\n%w", p.TrackingNumber, err)
+		}
+
+		shipment.Packages = append(shipment.Packages, ShippedPackage{
+			TrackingNumber: p.TrackingNumber,
+			LabelFormat:    p.LabelImage.LabelImageFormat.Code,
+			Label:          label,
+		})
+	}
+
+	return shipment, nil
+}
+
+// ConfirmShipment is the first step of Ship. It validates the shipment and
+// returns the quoted charges and the digest AcceptShipment needs.
+func (c *Client) ConfirmShipment(ctx context.Context, req *ShipmentConfirmRequest) (*ShipmentConfirmResponse, error) {
+	if len(req.Shipment.PaymentInformation) == 0 {
+		return nil, errors.New("ups.ConfirmShipment: Shipment.PaymentInformation is required")
+	}
+
+	request := *req
+	request.Lang = lang
+	request.Request.TransactionReference.XpciVersion = XpciVersion
+	request.Request.RequestAction = "ShipConfirm"
+	if request.Request.RequestOption == "" {
+		request.Request.RequestOption = "validate"
+	}
+
+	label := &request.LabelSpecification
+	if label.LabelPrintMethod.Code == "" {
+		label.LabelPrintMethod.Code = LabelImageGIF
+	}
+	if label.LabelImageFormat.Code == "" {
+		label.LabelImageFormat.Code = label.LabelPrintMethod.Code
+	}
+	if label.LabelImageFormat.Code == LabelImageGIF && label.HTTPUserAgent == "" {
+		label.HTTPUserAgent = "Mozilla/4.5"
+	}
+
+	response := new(ShipmentConfirmResponse)
+	if err := c.call(ctx, "ups.ConfirmShipment", "ShipConfirm", &request, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptShipment is the second step of Ship. It buys the labels for a
+// confirmed shipment.
+func (c *Client) AcceptShipment(ctx context.Context, digest string) (*ShipmentAcceptResponse, error) {
+	if digest == "" {
+		return nil, errors.New("ups.AcceptShipment: A shipment digest is required")
+	}
+
+	request := &ShipmentAcceptRequest{Lang: lang, ShipmentDigest: digest}
+	request.Request.TransactionReference.XpciVersion = XpciVersion
+	request.Request.RequestAction = "ShipAccept"
+	request.Request.RequestOption = "01"
+
+	response := new(ShipmentAcceptResponse)
+	if err := c.call(ctx, "ups.AcceptShipment", "ShipAccept", request, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (r *ShipmentConfirmResponse) quote() Quote {
+	return Quote{
+		ShipmentIdentificationNumber: r.ShipmentIdentificationNumber,
+		Transportation:               r.ShipmentCharges.TransportationCharges.MonetaryValue,
+		ServiceOptions:               r.ShipmentCharges.ServiceOptionsCharges.MonetaryValue,
+		Total:                        r.ShipmentCharges.TotalCharges.MonetaryValue,
+		Currency:                     r.ShipmentCharges.TotalCharges.CurrencyCode,
+		NegotiatedTotal:              r.NegotiatedRates.NetSummaryCharges.GrandTotal.MonetaryValue,
+		Warnings:                     r.Response.warnings(),
+	}
+}
@@ -0,0 +1,134 @@
+package ups
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+const (
+	confirmReply = `<ShipmentConfirmResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response>` +
+		`<ShipmentCharges><TransportationCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>14.80</MonetaryValue></TransportationCharges>` +
+		`<ServiceOptionsCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>9.50</MonetaryValue></ServiceOptionsCharges>` +
+		`<TotalCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>24.30</MonetaryValue></TotalCharges></ShipmentCharges>` +
+		`<ShipmentIdentificationNumber>1Z12345E0390817264</ShipmentIdentificationNumber><ShipmentDigest>DIGEST</ShipmentDigest>` +
+		`</ShipmentConfirmResponse>`
+
+	// Two packages whose GraphicImages are "GIF89a one" and "GIF89a two".
+	acceptReply = `<ShipmentAcceptResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response><ShipmentResults>` +
+		`<ShipmentCharges><TotalCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>24.30</MonetaryValue></TotalCharges></ShipmentCharges>` +
+		`<ShipmentIdentificationNumber>1Z12345E0390817264</ShipmentIdentificationNumber>` +
+		`<PackageResults><TrackingNumber>1Z12345E0390817264</TrackingNumber>` +
+		`<LabelImage><LabelImageFormat><Code>GIF</Code></LabelImageFormat><GraphicImage>R0lGODlhIG9uZQ==</GraphicImage></LabelImage></PackageResults>` +
+		`<PackageResults><TrackingNumber>1Z12345E0391234567</TrackingNumber>` +
+		`<LabelImage><LabelImageFormat><Code>GIF</Code></LabelImageFormat><GraphicImage>R0lGODlhIHR3bw==</GraphicImage></LabelImage></PackageResults>` +
+		`</ShipmentResults></ShipmentAcceptResponse>`
+)
+
+func testShipmentConfirmRequest() *ShipmentConfirmRequest {
+	req := &ShipmentConfirmRequest{Shipment: testShipment()}
+	payment := PaymentInformationType{}
+	payment.Prepaid.BillShipper.AccountNumber = "A12345"
+	req.Shipment.PaymentInformation = []PaymentInformationType{payment}
+	return req
+}
+
+func TestConfirmShipmentRequest(t *testing.T) {
+	c, requests := testClient(t, `<ShipmentConfirmResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response>`+
+		`<ShipmentIdentificationNumber>1Z12345E0390817264</ShipmentIdentificationNumber><ShipmentDigest>DIGEST</ShipmentDigest>`+
+		`</ShipmentConfirmResponse>`)
+
+	response, err := c.ConfirmShipment(context.Background(), testShipmentConfirmRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.ShipmentDigest != "DIGEST" {
+		t.Errorf("ShipmentDigest = %q, want DIGEST", response.ShipmentDigest)
+	}
+
+	r := (*requests)[0]
+	if r.Path != "/ShipConfirm" {
+		t.Errorf("posted to %s, want /ShipConfirm", r.Path)
+	}
+	checkEnvelope(t, r.Body, "ShipmentConfirmRequest")
+	checkIndicators(t, string(r.Body))
+	golden(t, "ship_confirm.xml", r.Body)
+}
+
+func TestAcceptShipmentRequest(t *testing.T) {
+	c, requests := testClient(t, `<ShipmentAcceptResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response></ShipmentAcceptResponse>`)
+
+	if _, err := c.AcceptShipment(context.Background(), "DIGEST"); err != nil {
+		t.Fatal(err)
+	}
+
+	r := (*requests)[0]
+	if r.Path != "/ShipAccept" {
+		t.Errorf("posted to %s, want /ShipAccept", r.Path)
+	}
+	checkEnvelope(t, r.Body, "ShipmentAcceptRequest")
+	golden(t, "ship_accept.xml", r.Body)
+}
+
+func TestShip(t *testing.T) {
+	c, requests := testClient(t, confirmReply, acceptReply)
+
+	var quoted Quote
+	shipment, err := c.Ship(context.Background(), testShipmentConfirmRequest(), func(q Quote) bool {
+		quoted = q
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if quoted.Transportation != 14.80 || quoted.ServiceOptions != 9.50 || quoted.Total != 24.30 || quoted.Currency != "USD" {
+		t.Errorf("approve was shown %+v, want the confirmed charges", quoted)
+	}
+
+	if len(*requests) != 2 || (*requests)[0].Path != "/ShipConfirm" || (*requests)[1].Path != "/ShipAccept" {
+		t.Fatalf("got %d requests, want /ShipConfirm then /ShipAccept", len(*requests))
+	}
+	if !bytes.Contains((*requests)[1].Body, []byte("<ShipmentDigest>DIGEST</ShipmentDigest>")) {
+		t.Errorf("ShipAccept did not send the confirmed digest:\n%s", (*requests)[1].Body)
+	}
+
+	if shipment.IdentificationNumber != "1Z12345E0390817264" || shipment.Total != 24.30 || shipment.Currency != "USD" {
+		t.Errorf("got %+v, want the accepted shipment", shipment)
+	}
+	want := []ShippedPackage{
+		{TrackingNumber: "1Z12345E0390817264", LabelFormat: LabelImageGIF, Label: []byte("GIF89a one")},
+		{TrackingNumber: "1Z12345E0391234567", LabelFormat: LabelImageGIF, Label: []byte("GIF89a two")},
+	}
+	if len(shipment.Packages) != len(want) {
+		t.Fatalf("got %d packages, want %d", len(shipment.Packages), len(want))
+	}
+	for i, p := range shipment.Packages {
+		if p.TrackingNumber != want[i].TrackingNumber || p.LabelFormat != want[i].LabelFormat || !bytes.Equal(p.Label, want[i].Label) {
+			t.Errorf("package %d = %+v, want %+v", i, p, want[i])
+		}
+	}
+}
+
+func TestShipNotApproved(t *testing.T) {
+	c, requests := testClient(t, confirmReply, acceptReply)
+
+	shipment, err := c.Ship(context.Background(), testShipmentConfirmRequest(), func(Quote) bool { return false })
+	if shipment != nil || !errors.Is(err, ErrNotApproved) {
+		t.Errorf("got %+v, %v; want ErrNotApproved", shipment, err)
+	}
+	if len(*requests) != 1 {
+		t.Errorf("sent %d requests, want the ShipConfirm alone", len(*requests))
+	}
+}
+
+func TestShipBadLabel(t *testing.T) {
+	c, _ := testClient(t, confirmReply, `<ShipmentAcceptResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response><ShipmentResults>`+
+		`<PackageResults><TrackingNumber>1Z12345E0390817264</TrackingNumber><LabelImage><GraphicImage>not base64!</GraphicImage></LabelImage></PackageResults>`+
+		`</ShipmentResults></ShipmentAcceptResponse>`)
+
+	if _, err := c.Ship(context.Background(), testShipmentConfirmRequest(), nil); err == nil {
+		t.Error("got no error for an undecodable label")
+	}
+}
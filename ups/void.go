@@ -0,0 +1,65 @@
+package ups
+
+import (
+	"context"
+	"errors"
+)
+
+type VoidedPackage struct {
+	TrackingNumber string
+	Voided         bool
+	Description    string // UPS's status text, e.g. "Voided".
+}
+
+type VoidResult struct {
+	ShipmentIdentificationNumber string
+
+	// Voided is true when UPS reports the request as a whole succeeded.
+	Voided bool
+
+	// Packages has one entry per package UPS reported on. UPS may leave it
+	// empty when a whole shipment is voided.
+	Packages []VoidedPackage
+}
+
+// Void cancels the shipment with the given identification number. With no
+// tracking numbers the whole shipment is voided; otherwise only the listed
+// packages are.
+func (c *Client) Void(ctx context.Context, shipmentID string, trackingNumbers ...string) (*VoidResult, error) {
+	if shipmentID == "" {
+		return nil, errors.New("ups.Void: A shipment identification number is required")
+	}
+
+	request := &VoidShipmentRequest{Lang: lang}
+	request.Request.TransactionReference.XpciVersion = XpciVersion
+	request.Request.RequestAction = "1"
+
+	if len(trackingNumbers) == 0 {
+		request.ShipmentIdentificationNumber = shipmentID
+	} else {
+		request.ExpandedVoidShipment = []ExpandedVoidShipmentType{{
+			ShipmentIdentificationNumber: shipmentID,
+			TrackingNumber:               trackingNumbers,
+		}}
+	}
+
+	var response VoidShipmentResponse
+	if err := c.call(ctx, "ups.Void", "Void", request, &response); err != nil {
+		return nil, err
+	}
+
+	result := &VoidResult{
+		ShipmentIdentificationNumber: shipmentID,
+		Voided:                       response.Status.StatusCode.Code == "1",
+	}
+
+	for _, p := range response.PackageLevelResults {
+		result.Packages = append(result.Packages, VoidedPackage{
+			TrackingNumber: p.TrackingNumber,
+			Voided:         p.StatusCode.Code == "1",
+			Description:    p.StatusCode.Description,
+		})
+	}
+
+	return result, nil
+}
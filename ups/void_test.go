@@ -0,0 +1,80 @@
+package ups
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const voidReply = `<VoidShipmentResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response>` +
+	`<Status><StatusType><Code>1</Code><Description>Success</Description></StatusType><StatusCode><Code>1</Code><Description>Success</Description></StatusCode></Status>` +
+	`</VoidShipmentResponse>`
+
+func TestVoidRequests(t *testing.T) {
+	tests := []struct {
+		name            string
+		trackingNumbers []string
+	}{
+		{"void_shipment", nil},
+		{"void_packages", []string{"1Z12345E0390817264", "1Z12345E0391234567"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, requests := testClient(t, voidReply)
+
+			result, err := c.Void(context.Background(), "1Z12345E0390817264", tt.trackingNumbers...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.Voided || result.ShipmentIdentificationNumber != "1Z12345E0390817264" || len(result.Packages) != 0 {
+				t.Errorf("got %+v, want the shipment voided with no package results", result)
+			}
+
+			r := (*requests)[0]
+			if r.Path != "/Void" {
+				t.Errorf("posted to %s, want /Void", r.Path)
+			}
+			checkEnvelope(t, r.Body, "VoidShipmentRequest")
+			golden(t, tt.name+".xml", r.Body)
+		})
+	}
+}
+
+func TestVoidPackageResults(t *testing.T) {
+	reply, err := os.ReadFile(filepath.Join("testdata", "void_packages_response.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := testClient(t, string(reply))
+
+	result, err := c.Void(context.Background(), "1Z12345E0390817264", "1Z12345E0390817264", "1Z12345E0391234567")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &VoidResult{
+		ShipmentIdentificationNumber: "1Z12345E0390817264",
+		Voided:                       true,
+		Packages: []VoidedPackage{
+			{TrackingNumber: "1Z12345E0390817264", Voided: true, Description: "Voided"},
+			{TrackingNumber: "1Z12345E0391234567", Voided: false, Description: "Not Voided"},
+		},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("got %+v\nwant %+v", result, want)
+	}
+}
+
+func TestVoidShipmentIDRequired(t *testing.T) {
+	c, requests := testClient(t, voidReply)
+
+	if _, err := c.Void(context.Background(), "", "1Z12345E0390817264"); err == nil {
+		t.Error("got no error without a shipment identification number")
+	}
+	if len(*requests) != 0 {
+		t.Errorf("sent %d requests, want none", len(*requests))
+	}
+}
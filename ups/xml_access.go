@@ -1,8 +1,12 @@
 package ups
 
-import ()
+import (
+	"encoding/xml"
+)
 
 type AccessRequest struct {
+	XMLName             xml.Name `xml:"AccessRequest"`
+	Lang                string   `xml:"xml:lang,attr"` // Always "en-US".
 	AccessLicenseNumber string
 	UserId              string
 	Password            string
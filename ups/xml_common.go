@@ -14,11 +14,57 @@ objects in the slice, no XML is rendered for the sub-struct.
 The alternative is to create distinct kinds of sub-structs depending on the kind
 of request.
 
-At some point, we might think about validating for the correct number of 
-sub-structs before requests are actually made. 
+At some point, we might think about validating for the correct number of
+sub-structs before requests are actually made.
 */
 
-import ()
+import (
+	"encoding/xml"
+)
+
+// The XPCI version every request is sent as.
+const XpciVersion = "1.0001"
+
+// The language attribute UPS requires on every root element.
+const lang = "en-US"
+
+// Indicator is an element whose presence alone carries the meaning, e.g.
+// <AdditionalHandling/>. True renders the empty element; false renders
+// nothing when the field is tagged omitempty.
+type Indicator bool
+
+func (i Indicator) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !i {
+		return nil
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+func (i *Indicator) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*i = true
+	return d.Skip()
+}
+
+type TransactionReferenceType struct {
+	CustomerContext string `xml:",omitempty"`
+	XpciVersion     string
+}
+
+type ResponseType struct {
+	TransactionReference      TransactionReferenceType
+	ResponseStatusCode        int // 1 = success, 0 = failure.
+	ResponseStatusDescription string
+	Error                     []Error
+}
+
+type RequestType struct {
+	TransactionReference TransactionReferenceType
+	RequestAction        string
+	RequestOption        string `xml:",omitempty"`
+}
 
 type AddressType struct {
 	AddressLine1                string
@@ -28,7 +74,7 @@ type AddressType struct {
 	StateProvinceCode           string
 	PostalCode                  string
 	CountryCode                 string
-	ResidentialAddressIndicator string `xml:",omitempty"`
+	ResidentialAddressIndicator Indicator `xml:",omitempty"`
 }
 
 type ShipperType struct {
@@ -48,22 +94,20 @@ type ShipFromType struct {
 }
 
 type ShipmentType struct {
-	Description string
+	Description string `xml:",omitempty"`
 	Shipper     ShipperType
 	ShipTo      ShipToType
 	ShipFrom    ShipFromType
 	Service     struct {
-		Code ServiceCode
-	}
-	DocumentsOnly      string
-	NumOfPieces        string
-	PaymentInformation struct {
-		Prepaid struct {
-			BillShipper struct {
-				AccountNumber string
-			}
-		}
+		Code        ServiceCode
+		Description string `xml:",omitempty"`
 	}
+	DocumentsOnly Indicator `xml:",omitempty"`
+	NumOfPieces   string    `xml:",omitempty"`
+
+	// Required for shipping; must be left out when rating.
+	PaymentInformation []PaymentInformationType
+
 	Packages               []PackageType `xml:"Package"`
 	ShipmentServiceOptions []struct {
 		OnCallAir struct {
@@ -74,62 +118,65 @@ type ShipmentType struct {
 		}
 	}
 	RateInformation []struct {
-		NegotiatedRatesIndicator string `xml:",omitempty"`
-		RateChartIndicator       string `xml:",omitempty"`
+		NegotiatedRatesIndicator Indicator `xml:",omitempty"`
+		RateChartIndicator       Indicator `xml:",omitempty"`
 	}
 	InvoiceLineTotal []struct {
 		CurrencyCode  string
 		MonetaryValue float64
 	}
-	ItemizedChargesRequestedIndicator string `xml:",omitempty"`
+	ItemizedChargesRequestedIndicator Indicator `xml:",omitempty"`
+}
+
+type PaymentInformationType struct {
+	Prepaid struct {
+		BillShipper struct {
+			AccountNumber string
+		}
+	}
 }
 
 type PackageType struct {
 	PackagingType struct {
 		Code        PackagingTypeCode
-		Description string
+		Description string `xml:",omitempty"`
 	}
-	Description     string // Merchandise description of package.
+	Description     string `xml:",omitempty"` // Merchandise description of package.
 	ReferenceNumber []struct {
 		Code  string
 		Value string
 	}
+	Dimensions    []DimensionsType
 	PackageWeight struct {
 		// Weight:
-		// Assume pounds (fixed in template).
+		// Assume pounds unless UnitOfMeasurement says otherwise.
 		// Precision: 6.1
 		// Valid Range: 0.1-150.0
-		UnitOfMeasurement struct {
-			Code        string
-			Description string
-		}
-		Weight float64
+		UnitOfMeasurement UnitOfMeasurementType
+		Weight            float64
 	}
-	LargePackageIndicator bool `xml:",omitempty"`
+	LargePackageIndicator Indicator `xml:",omitempty"`
 
 	// Additional Handling:
 	// The presence indicates additional handling is required.
 	// The absence indicates no additional handling is required.
-	AdditionalHandling bool `xml:",omitempty"`
-
-	Dimensions []struct {
-		// Width/Length/Height:
-		// Required if Packaging Type is not
-		// Letter, Express Tube, or Express Box;
-		// Required for 'GB to GB' and 'Poland to Poland' shipments
-		// Precision: 6.2
-
-		UnitOfMeasurement struct {
-			Code        string
-			Description string
-		}
-		Width  float64
-		Height float64
-		Length float64
-	}
+	AdditionalHandling Indicator `xml:",omitempty"`
+}
+
+type DimensionsType struct {
+	// Width/Length/Height:
+	// Required if Packaging Type is not
+	// Letter, Express Tube, or Express Box;
+	// Required for 'GB to GB' and 'Poland to Poland' shipments
+	// Precision: 6.2
+
+	UnitOfMeasurement UnitOfMeasurementType
+	Length            float64
+	Width             float64
+	Height            float64
 }
 
 type UnitOfMeasurementType struct {
 	Code        UnitOfMeasurementCode
-	Description string
+	Description string `xml:",omitempty"`
 }
@@ -0,0 +1,61 @@
+package ups
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestIndicator(t *testing.T) {
+	tests := []struct {
+		name    string
+		address AddressType
+		want    string
+	}{
+		{"true", AddressType{City: "Atlanta", ResidentialAddressIndicator: true},
+			"<AddressType><AddressLine1></AddressLine1><City>Atlanta</City><StateProvinceCode></StateProvinceCode><PostalCode></PostalCode><CountryCode></CountryCode><ResidentialAddressIndicator></ResidentialAddressIndicator></AddressType>"},
+		{"false", AddressType{City: "Atlanta"},
+			"<AddressType><AddressLine1></AddressLine1><City>Atlanta</City><StateProvinceCode></StateProvinceCode><PostalCode></PostalCode><CountryCode></CountryCode></AddressType>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := xml.Marshal(tt.address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("got  %s\nwant %s", data, tt.want)
+			}
+		})
+	}
+}
+
+// Without omitempty a false Indicator must still render nothing.
+func TestIndicatorWithoutOmitempty(t *testing.T) {
+	type flags struct {
+		On  Indicator
+		Off Indicator
+	}
+
+	data, err := xml.Marshal(flags{On: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<flags><On></On></flags>"; string(data) != want {
+		t.Errorf("got  %s\nwant %s", data, want)
+	}
+}
+
+func TestIndicatorUnmarshal(t *testing.T) {
+	var flags struct {
+		On  Indicator
+		Off Indicator
+	}
+
+	if err := xml.Unmarshal([]byte("<flags><On/></flags>"), &flags); err != nil {
+		t.Fatal(err)
+	}
+	if !flags.On || flags.Off {
+		t.Errorf("got On=%v Off=%v, want On=true Off=false", flags.On, flags.Off)
+	}
+}
@@ -1,21 +1,16 @@
 package ups
 
-import ()
+import (
+	"encoding/xml"
+)
 
 type RatingServiceSelectionResponse struct {
-	Response struct {
-		ResponseStatusCode        int
-		ResponseStatusDescription string `xml:",omitempty"`
-		TransactionReference      struct {
-			CustomerContext string
-			XpciVersion     string
-		}
-	}
+	Response      ResponseType
 	RatedShipment []struct {
 		Service struct {
-			Code int
+			Code ServiceCode
 		}
-		RatedShipmentWarning string
+		RatedShipmentWarning []string
 		BillingWeight        struct {
 			UnitOfMeasurement struct {
 				Code string
@@ -67,17 +62,12 @@ type RatingServiceSelectionResponse struct {
 }
 
 type RatingServiceSelectionRequest struct {
-	Request struct {
-		TransactionReference struct {
-			CustomerContext string
-			XpciVersion     string
-		}
-		RequestAction string
-		RequestOption string
-	}
+	XMLName    xml.Name `xml:"RatingServiceSelectionRequest"`
+	Lang       string   `xml:"xml:lang,attr"`
+	Request    RequestType
 	PickupType struct {
 		Code        PickupTypeCode
-		Description string
+		Description string `xml:",omitempty"`
 	}
 	CustomerClassification []struct {
 		Code CustomerClassificationCode
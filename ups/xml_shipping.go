@@ -1,68 +1,78 @@
 package ups
 
-import ()
+import (
+	"encoding/xml"
+)
 
 type ShipmentConfirmRequest struct {
-	Shipper  ShipperType
-	ShipTo   ShipToType
-	ShipFrom ShipFromType
-
-	Request struct {
-		TransactionReference struct {
-			CustomerContext string
-		}
-		RequestAction string
-		RequestOption string
-	}
-
+	XMLName            xml.Name `xml:"ShipmentConfirmRequest"`
+	Lang               string   `xml:"xml:lang,attr"`
+	Request            RequestType
+	Shipment           ShipmentType
 	LabelSpecification struct {
 		LabelPrintMethod struct {
-			Code        string
-			Description string
+			Code        LabelImageFormatCode
+			Description string `xml:",omitempty"`
 		}
-		HTTPUserAgent    string
+		HTTPUserAgent    string `xml:",omitempty"`
 		LabelImageFormat struct {
 			Code        LabelImageFormatCode
-			Description string
+			Description string `xml:",omitempty"`
 		}
-		LabelStockSize struct {
+
+		// Only for thermal (EPL, ZPL, SPL, STARPL) labels.
+		LabelStockSize []struct {
 			// In inches, whole numbers only.
-			Width  int
 			Height int
+			Width  int
 		}
 	}
-	Shipment ShipmentType
-	Service  struct {
-		Code        ServiceCode
-		Description string
-	}
-	Packages []PackageType `xml:"Package"`
 }
 
 type ShipmentAcceptRequest struct {
-	Request struct {
-		TransactionReference struct {
-			CustomerContext string
-		}
-		RequestAction string
-		RequestOption string
-	}
+	XMLName        xml.Name `xml:"ShipmentAcceptRequest"`
+	Lang           string   `xml:"xml:lang,attr"`
+	Request        RequestType
 	ShipmentDigest string
 }
 
-type ShipmentAcceptResponse struct {
-	Response struct {
-		ResponseStatusCode        int
-		ResponseStatusDescription string
-		TransactionReference      struct {
-			CustomerContext string
+type ShipmentConfirmResponse struct {
+	XMLName         xml.Name `xml:"ShipmentConfirmResponse"`
+	Response        ResponseType
+	ShipmentCharges struct {
+		TransportationCharges struct {
+			CurrencyCode  string
+			MonetaryValue float64
 		}
-		Error []struct {
-			ErrorSeverity    string
-			ErrorCode        int
-			ErrorDescription string
+		ServiceOptionsCharges struct {
+			CurrencyCode  string
+			MonetaryValue float64
+		}
+		TotalCharges struct {
+			CurrencyCode  string
+			MonetaryValue float64
+		}
+	}
+	NegotiatedRates struct {
+		NetSummaryCharges struct {
+			GrandTotal struct {
+				CurrencyCode  string
+				MonetaryValue float64
+			}
 		}
 	}
+	BillingWeight struct {
+		UnitOfMeasurement struct {
+			Code string
+		}
+		Weight float64
+	}
+	ShipmentIdentificationNumber string
+	ShipmentDigest               string
+}
+
+type ShipmentAcceptResponse struct {
+	Response        ResponseType
 	ShipmentResults struct {
 		ShipmentCharges struct {
 			TransportationCharges struct {
@@ -102,7 +112,7 @@ type ShipmentAcceptResponse struct {
 			}
 			LabelImage struct {
 				LabelImageFormat struct {
-					Code string
+					Code LabelImageFormatCode
 				}
 				GraphicImage string
 				HTMLImage    string
@@ -0,0 +1,38 @@
+package ups
+
+import (
+	"encoding/xml"
+)
+
+// A whole shipment is voided through ShipmentIdentificationNumber; some of
+// its packages through ExpandedVoidShipment. Only one of the two is sent.
+type VoidShipmentRequest struct {
+	XMLName                      xml.Name `xml:"VoidShipmentRequest"`
+	Lang                         string   `xml:"xml:lang,attr"`
+	Request                      RequestType
+	ShipmentIdentificationNumber string `xml:",omitempty"`
+	ExpandedVoidShipment         []ExpandedVoidShipmentType
+}
+
+type ExpandedVoidShipmentType struct {
+	ShipmentIdentificationNumber string
+	TrackingNumber               []string
+}
+
+type VoidStatusType struct {
+	Code        string // "1" = success.
+	Description string
+}
+
+type VoidShipmentResponse struct {
+	XMLName  xml.Name `xml:"VoidShipmentResponse"`
+	Response ResponseType
+	Status   struct {
+		StatusType VoidStatusType
+		StatusCode VoidStatusType
+	}
+	PackageLevelResults []struct {
+		TrackingNumber string
+		StatusCode     VoidStatusType
+	}
+}
@@ -0,0 +1,97 @@
+package shipping
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/functionary/shipping/ups"
+)
+
+const upsRateReply = `<RatingServiceSelectionResponse><Response><ResponseStatusCode>1</ResponseStatusCode></Response>` +
+	`<RatedShipment><Service><Code>03</Code></Service><TotalCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>12.34</MonetaryValue></TotalCharges></RatedShipment>` +
+	`<RatedShipment><Service><Code>02</Code></Service><TotalCharges><CurrencyCode>USD</CurrencyCode><MonetaryValue>25.10</MonetaryValue></TotalCharges></RatedShipment>` +
+	`</RatingServiceSelectionResponse>`
+
+func testUPSRater(t *testing.T) (*UPSRater, *[]string) {
+	t.Helper()
+
+	baseURL, bodies := testServer(t, upsRateReply)
+	client := ups.NewClient("LICENSE", "USER", "PASSWORD")
+	client.BaseURL = baseURL
+	return &UPSRater{Client: client}, bodies
+}
+
+func TestUPSRaterRate(t *testing.T) {
+	r, bodies := testUPSRater(t)
+
+	from := Address{Street1: "1 Main St", City: "Timonium", State: "MD", PostalCode: "21093"}
+	to := Address{Company: "Customer", Street1: "2 Elm St", City: "Atlanta", State: "GA", PostalCode: "30328"}
+
+	e, err := r.Rate(context.Background(), string(ups.ServiceUSGround), from, to, Package{Weight: 10, Width: 10, Height: 4, Length: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Estimate{Name: "Ground", Provider: UPS, Service: string(ups.ServiceUSGround), Price: 12.34}
+	if e != want {
+		t.Errorf("got %+v, want %+v", e, want)
+	}
+
+	body := (*bodies)[0]
+	for _, s := range []string{
+		"<PickupType><Code>01</Code></PickupType>",
+		"<Service><Code>03</Code></Service>",
+		"<ShipTo><CompanyName>Customer</CompanyName><Address><AddressLine1>2 Elm St</AddressLine1><City>Atlanta</City><StateProvinceCode>GA</StateProvinceCode><PostalCode>30328</PostalCode><CountryCode>US</CountryCode></Address></ShipTo>",
+		"<Weight>0.7</Weight>",
+		"<Length>12</Length><Width>10</Width><Height>4</Height>",
+	} {
+		if !strings.Contains(body, s) {
+			t.Errorf("request is missing %s:\n%s", s, body)
+		}
+	}
+}
+
+func TestUPSRaterShop(t *testing.T) {
+	r, _ := testUPSRater(t)
+
+	estimates, err := r.Shop(context.Background(), Address{PostalCode: "21093"}, Address{PostalCode: "30328"}, Package{Weight: 32})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Estimate{
+		{Name: "Ground", Provider: UPS, Service: "03", Price: 12.34},
+		{Name: "2nd Day Air", Provider: UPS, Service: "02", Price: 25.10},
+	}
+	if len(estimates) != len(want) {
+		t.Fatalf("got %d estimates, want %d", len(estimates), len(want))
+	}
+	for i := range want {
+		if estimates[i] != want[i] {
+			t.Errorf("estimate %d = %+v, want %+v", i, estimates[i], want[i])
+		}
+	}
+}
+
+func TestUPSPounds(t *testing.T) {
+	tests := []struct {
+		ounces, pounds float64
+	}{
+		{0.5, 0.1},
+		{1, 0.1},
+		{1.6, 0.1},
+		{1.7, 0.2},
+		{8, 0.5},
+		{10, 0.7},
+		{16, 1},
+		{16.1, 1.1},
+		{2400, 150},
+	}
+
+	for _, tt := range tests {
+		if got := upsPounds(tt.ounces); got != tt.pounds {
+			t.Errorf("upsPounds(%v) = %v, want %v", tt.ounces, got, tt.pounds)
+		}
+	}
+}
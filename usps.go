@@ -0,0 +1,113 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/functionary/shipping/usps"
+)
+
+// USPSRater adapts the usps package to the Rater interface.
+type USPSRater struct {
+	Client *usps.Client
+
+	// Container is used for every package. Defaults to usps.ContainerVariable,
+	// or usps.ContainerRectangular for packages with any side over 12".
+	Container usps.Container
+
+	// FirstClassType is sent for First-Class services, which USPS cannot rate
+	// without it. Defaults to usps.FirstClassParcel.
+	FirstClassType usps.FirstClassType
+}
+
+func (r *USPSRater) Carrier() Carrier {
+	return USPS
+}
+
+func (r *USPSRater) Rate(ctx context.Context, service string, from, to Address, p Package) (Estimate, error) {
+	pkg, err := r.pkg(usps.Service(service), from, to, p)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	e, err := r.Client.Rate(ctx, pkg)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	return fromUSPS(e), nil
+}
+
+func (r *USPSRater) Shop(ctx context.Context, from, to Address, p Package) ([]Estimate, error) {
+	pkg, err := r.pkg(usps.ServiceAll, from, to, p)
+	if err != nil {
+		return nil, err
+	}
+	request := &usps.RateRequest{Packages: []usps.Package{pkg}}
+
+	// A per-package error may come back alongside the estimates that did
+	// succeed, so both are passed on.
+	list, err := r.Client.Shop(ctx, request)
+
+	estimates := make([]Estimate, 0, len(list))
+	for _, e := range list {
+		estimates = append(estimates, fromUSPS(e))
+	}
+
+	return estimates, err
+}
+
+func (r *USPSRater) pkg(service usps.Service, from, to Address, p Package) (usps.Package, error) {
+	if !domestic(from) || !domestic(to) {
+		return usps.Package{}, errors.New("shipping.USPSRater: Only domestic addresses are supported")
+	}
+
+	// USPS wants the container shape for packages with any side over 12".
+	container := r.Container
+	if container == "" {
+		container = usps.ContainerVariable
+		if p.Width > 12 || p.Height > 12 || p.Length > 12 {
+			container = usps.ContainerRectangular
+		}
+	}
+
+	firstClassType := r.FirstClassType
+	if firstClassType == "" {
+		firstClassType = usps.FirstClassParcel
+	}
+
+	return usps.Package{
+		Service:        service,
+		FirstClassType: firstClassType,
+		Container:      container,
+		ZipFrom:        zip5(from.PostalCode),
+		ZipTo:          zip5(to.PostalCode),
+		Weight:         p.Weight,
+		Width:          p.Width,
+		Height:         p.Height,
+		Length:         p.Length,
+	}, nil
+}
+
+func fromUSPS(e usps.Estimate) Estimate {
+	return Estimate{
+		Name:     e.Description,
+		Provider: USPS,
+		Service:  string(e.Service),
+		Price:    e.Cost,
+	}
+}
+
+// RateV4 only takes the 5-digit ZIP, so a ZIP+4 is cut down to it.
+func zip5(postalCode string) string {
+	zip, _, _ := strings.Cut(strings.TrimSpace(postalCode), "-")
+	if len(zip) > 5 {
+		zip = zip[:5]
+	}
+	return zip
+}
+
+func domestic(a Address) bool {
+	return a.Country == "" || a.Country == "US"
+}
@@ -1,6 +1,123 @@
 package usps
 
-import ()
+import (
+	"context"
+)
 
+// A domestic address, as understood by the USPS Address Information APIs.
 type Address struct {
+	// Name and Phone are only used on labels and pickup requests.
+	Name  string
+	Phone string
+
+	Firm string
+
+	// Address1 is the street address and Address2 the apartment, suite or
+	// other secondary unit. (USPS itself uses the opposite order; the
+	// conversion happens when requests are built.)
+	Address1 string
+	Address2 string
+
+	City  string
+	State string // Two-letter state code.
+	Zip5  string
+	Zip4  string
+
+	// Urbanization is only used for addresses in Puerto Rico.
+	Urbanization string
+}
+
+// VerifiedAddress is the standardized form of an Address returned by
+// VerifyAddress, with the delivery data USPS attaches to it.
+type VerifiedAddress struct {
+	Address
+
+	DeliveryPoint string
+	CarrierRoute  string
+
+	// DPVConfirmation is the Delivery Point Validation result:
+	//	Y = the address was confirmed,
+	//	D = the primary number was confirmed, the secondary number is missing,
+	//	S = the primary number was confirmed, the secondary number was not,
+	//	N = the address could not be confirmed.
+	DPVConfirmation string
+
+	// Residential is derived from the USPS business flag. It is only a hint;
+	// USPS does not claim its business data is complete.
+	Residential bool
+
+	// Vacant is set when USPS has flagged the address as unoccupied, and CMRA
+	// when it is a Commercial Mail Receiving Agency such as a mailbox store.
+	Vacant bool
+	CMRA   bool
+
+	// ReturnText carries USPS notes such as "Default address: The address you
+	// entered was found but more information is needed".
+	ReturnText string
+	Footnotes  string
+}
+
+// VerifyAddress standardizes a and confirms that USPS delivers to it.
+func (c *Client) VerifyAddress(ctx context.Context, a Address) (VerifiedAddress, error) {
+	var verified VerifiedAddress
+
+	request := &AddressValidateRequest{
+		UserId:    c.UserId,
+		Revision:  "1",
+		Addresses: []RequestAddress{a.request("0")},
+	}
+
+	var response AddressValidateResponse
+	if err := c.call(ctx, "usps.VerifyAddress", "Verify", request, &response); err != nil {
+		return verified, err
+	}
+
+	if len(response.Addresses) == 0 {
+		return verified, &APIError{Description: "No address returned", ID: "0"}
+	}
+
+	r := response.Addresses[0]
+	if r.Error != nil {
+		r.Error.ID = r.ID
+		return verified, r.Error
+	}
+
+	verified.Address = r.address()
+	verified.DeliveryPoint = r.DeliveryPoint
+	verified.CarrierRoute = r.CarrierRoute
+	verified.DPVConfirmation = r.DPVConfirmation
+	verified.Residential = r.Business == "N"
+	verified.Vacant = r.Vacant == "Y"
+	verified.CMRA = r.DPVCMRA == "Y"
+	verified.ReturnText = r.ReturnText
+	verified.Footnotes = r.Footnotes
+
+	return verified, nil
+}
+
+func (a *Address) request(id string) RequestAddress {
+	return RequestAddress{
+		ID:           id,
+		FirmName:     a.Firm,
+		Address1:     a.Address2,
+		Address2:     a.Address1,
+		City:         a.City,
+		State:        a.State,
+		Urbanization: a.Urbanization,
+		Zip5:         a.Zip5,
+		Zip4:         a.Zip4,
+	}
+}
+
+func (r *ResponseAddress) address() Address {
+	return Address{
+		Firm:         r.FirmName,
+		Address1:     r.Address2,
+		Address2:     r.Address1,
+		City:         r.City,
+		State:        r.State,
+		Urbanization: r.Urbanization,
+		Zip5:         r.Zip5,
+		Zip4:         r.Zip4,
+	}
 }
@@ -0,0 +1,140 @@
+package usps
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// Envelopes are flat, but will close over a thin item. This is how thick an
+// item may be and still be offered a flat rate envelope.
+const envelopeThickness = 1 // Inches.
+
+// Inside dimensions (inches) and weight limits (ounces) of the Priority Mail
+// flat rate and regional rate packaging. Envelopes have no height.
+var packaging = []struct {
+	Container             Container
+	Width, Length, Height float64
+	MaxWeight             float64
+}{
+	{ContainerFlatRateEnv, 9.5, 12.5, 0, maxWeight},
+	{ContainerFlatRateEnvPadded, 9.5, 12.5, 0, maxWeight},
+	{ContainerFlatRateEnvLegal, 9.5, 15, 0, maxWeight},
+	{ContainerFlatRateEnvSmall, 6, 10, 0, maxWeight},
+	{ContainerFlatRateEnvWindow, 5, 10, 0, maxWeight},
+	{ContainerFlatRateEnvGiftCard, 7, 10, 0, maxWeight},
+	{ContainerBoxFlatRateSmall, 5.375, 8.625, 1.625, maxWeight},
+	{ContainerBoxFlatRate, 8.5, 11, 5.5, maxWeight},
+	{ContainerBoxFlatRateMedium, 8.5, 11, 5.5, maxWeight},
+	{ContainerBoxFlatRateLarge, 12, 12, 5.5, maxWeight},
+	{ContainerRegionalRateBoxA, 7, 10, 4.75, 15 * 16},
+	{ContainerRegionalRateBoxB, 10.25, 12, 5, 20 * 16},
+	{ContainerRegionalRateBoxC, 12, 15, 12, 25 * 16},
+}
+
+// ContainerOption is one way of packing an item, priced for Priority Mail.
+type ContainerOption struct {
+	Container Container
+	Estimate  Estimate
+
+	// Err is set when USPS would not price this option; Estimate is then
+	// zero-valued.
+	Err error
+}
+
+// FitContainers lists the flat rate and regional rate containers an item of
+// the given size (inches) and weight (ounces) physically fits in. The item
+// may be turned any way round.
+func FitContainers(width, height, length, weight float64) []Container {
+	item := []float64{width, height, length}
+	sort.Float64s(item)
+
+	var fits []Container
+	for _, p := range packaging {
+		if weight > p.MaxWeight {
+			continue
+		}
+
+		height := p.Height
+		if height == 0 {
+			height = envelopeThickness
+		}
+		box := []float64{p.Width, p.Length, height}
+		sort.Float64s(box)
+
+		if item[0] <= box[0] && item[1] <= box[1] && item[2] <= box[2] {
+			fits = append(fits, p.Container)
+		}
+	}
+
+	return fits
+}
+
+// AdviseContainers prices p for Priority Mail in every flat rate and regional
+// rate container it fits in, as well as in the packer's own box, and returns
+// the options cheapest first. Options USPS did not price come last, with Err
+// set. p.Service and p.Container are ignored.
+func (c *Client) AdviseContainers(ctx context.Context, p Package) ([]ContainerOption, error) {
+	own := ContainerVariable
+	if (p.Width > 12) || (p.Height > 12) || (p.Length > 12) {
+		own = ContainerRectangular
+	}
+
+	containers := append([]Container{own}, FitContainers(p.Width, p.Height, p.Length, p.Weight)...)
+
+	options := make([]ContainerOption, len(containers))
+	request := &RateRequest{Packages: make([]Package, len(containers))}
+	for i, container := range containers {
+		options[i].Container = container
+
+		pkg := p
+		pkg.Service = ServicePriority
+		pkg.Container = container
+		if i > 0 {
+			// Flat rate pricing does not depend on the item's dimensions.
+			pkg.Width, pkg.Height, pkg.Length = 0, 0, 0
+		}
+		request.Packages[i] = pkg
+	}
+
+	// One request rates every option; package IDs are the option indexes.
+	estimates, err := c.rate(ctx, "usps.AdviseContainers", request)
+	if err != nil {
+		var errs Errors
+		if !errors.As(err, &errs) {
+			return nil, err
+		}
+		for _, apiErr := range errs {
+			if i, ok := batchIndex(apiErr.ID, 0, len(options)); ok {
+				options[i].Err = apiErr
+			}
+		}
+	}
+
+	found := make([]bool, len(options))
+	for _, e := range estimates {
+		i, ok := batchIndex(e.PackageID, 0, len(options))
+		if !ok || e.Service != ServicePriority {
+			continue
+		}
+		if !found[i] || e.Cost < options[i].Estimate.Cost {
+			options[i].Estimate = e
+			found[i] = true
+		}
+	}
+
+	for i := range options {
+		if !found[i] && options[i].Err == nil {
+			options[i].Err = &NoRateError{Service: ServicePriority}
+		}
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		if (options[i].Err == nil) != (options[j].Err == nil) {
+			return options[i].Err == nil
+		}
+		return options[i].Estimate.Cost < options[j].Estimate.Cost
+	})
+
+	return options, nil
+}
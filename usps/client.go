@@ -0,0 +1,175 @@
+package usps
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// USPS Web Tools endpoints. Test credentials only work against TestingURL.
+const (
+	ProductionURL = "https://secure.shippingapis.com/ShippingAPI.dll"
+	TestingURL    = "https://secure.shippingapis.com/ShippingAPITest.dll"
+)
+
+// Client talks to the USPS Web Tools API on behalf of a single USERID.
+type Client struct {
+	UserId string
+
+	// BaseURL defaults to ProductionURL.
+	BaseURL string
+
+	// SourceID identifies the integrating company to USPS. Some APIs, such as
+	// tracking, require it.
+	SourceID string
+
+	// ClientIP is the IP address of the end user the request is made for.
+	// Tracking requires it alongside SourceID.
+	ClientIP string
+
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Trace, if set, is called with every XML document sent to and received
+	// from USPS. The USERID attribute of requests is redacted before Trace
+	// sees the data. Nothing is logged when Trace is nil.
+	Trace func(dir Direction, data []byte)
+}
+
+// Direction tells a Trace hook which way a document was travelling.
+type Direction int
+
+const (
+	TraceRequest Direction = iota
+	TraceResponse
+)
+
+func (d Direction) String() string {
+	if d == TraceRequest {
+		return "request"
+	}
+	return "response"
+}
+
+const redacted = "REDACTED"
+
+// Returns a Client for the production endpoint.
+func NewClient(userId string) *Client {
+	return &Client{UserId: userId, BaseURL: ProductionURL}
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+	return ProductionURL
+}
+
+// Sends an XML document to the named Web Tools API and returns the raw
+// response body.
+func (c *Client) send(ctx context.Context, api string, data []byte) ([]byte, error) {
+	c.trace(TraceRequest, data)
+
+	values := url.Values{}
+	values.Add("API", api)
+	values.Add("XML", string(data))
+
+	request, err := http.NewRequestWithContext(ctx, "POST", c.baseURL(), strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("usps.send: Unable to build request:\n%w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := c.httpClient().Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("usps.send: Error while sending XML request:\n%w", err)
+	}
+	defer response.Body.Close()
+
+	rawxml, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("usps.send: Error while reading response:\n%w", err)
+	}
+
+	c.trace(TraceResponse, rawxml)
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("usps.send: Unexpected HTTP status %s", response.Status)
+	}
+
+	return rawxml, nil
+}
+
+// Marshals request, sends it to the named API and unmarshals the reply into
+// response. A top-level USPS <Error> is returned as an *APIError; other
+// failures are prefixed with caller.
+func (c *Client) call(ctx context.Context, caller, api string, request, response interface{}) error {
+	buf := new(bytes.Buffer)
+	buf.WriteString(xml.Header)
+	if err := xml.NewEncoder(buf).Encode(request); err != nil {
+		return fmt.Errorf("%s: XML marshalling failed:\n%w", caller, err)
+	}
+
+	rawxml, err := c.send(ctx, api, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: Data send failed:\n%w", caller, err)
+	}
+
+	err = decodeResponse(rawxml, response)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			return err
+		}
+		return fmt.Errorf("%s: XML unmarshalling failed:\n%w", caller, err)
+	}
+
+	return nil
+}
+
+// Matches the USERID attribute of a request's root element, after the
+// optional XML declaration.
+var userIDAttr = regexp.MustCompile(`^\s*(?:<\?xml[^>]*\?>\s*)?<[^\s>]+\s+(?:[^>]*\s)?USERID="([^"]*)"`)
+
+// Hands a copy of data to the Trace hook. Requests have the USERID attribute
+// of their root element blanked out; responses are passed on unchanged.
+func (c *Client) trace(dir Direction, data []byte) {
+	if c.Trace == nil {
+		return
+	}
+
+	if dir == TraceRequest {
+		data = redactUserID(data)
+	} else {
+		data = bytes.Clone(data)
+	}
+
+	c.Trace(dir, data)
+}
+
+// Returns a copy of a request document with its USERID replaced by redacted.
+func redactUserID(data []byte) []byte {
+	m := userIDAttr.FindSubmatchIndex(data)
+	if m == nil {
+		return bytes.Clone(data)
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:m[2]]...)
+	out = append(out, redacted...)
+	return append(out, data[m[3]:]...)
+}
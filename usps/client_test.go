@@ -0,0 +1,190 @@
+package usps
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A recorded Web Tools request.
+type sent struct {
+	API string
+	XML string
+}
+
+// Starts a server that answers requests with replies in turn, repeating the
+// last one, and returns a Client pointed at it along with the requests it
+// received.
+func testClient(t *testing.T, replies ...string) (*Client, *[]sent) {
+	t.Helper()
+
+	var requests []sent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		requests = append(requests, sent{API: r.PostForm.Get("API"), XML: r.PostForm.Get("XML")})
+		io.WriteString(w, replies[min(len(requests), len(replies))-1])
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient("TESTUSER")
+	c.BaseURL = server.URL
+	return c, &requests
+}
+
+type echoRequest struct {
+	XMLName xml.Name `xml:"EchoRequest"`
+	UserId  string   `xml:"USERID,attr"`
+	Value   string
+}
+
+type echoResponse struct {
+	XMLName xml.Name `xml:"EchoResponse"`
+	Value   string
+}
+
+func TestBaseURL(t *testing.T) {
+	if got := (&Client{}).baseURL(); got != ProductionURL {
+		t.Errorf("zero Client uses %s, want %s", got, ProductionURL)
+	}
+	if got := NewClient("TESTUSER").baseURL(); got != ProductionURL {
+		t.Errorf("NewClient uses %s, want %s", got, ProductionURL)
+	}
+	if got := (&Client{BaseURL: TestingURL}).baseURL(); got != TestingURL {
+		t.Errorf("Client with BaseURL uses %s, want %s", got, TestingURL)
+	}
+}
+
+func TestCall(t *testing.T) {
+	c, requests := testClient(t, `<?xml version="1.0" encoding="UTF-8"?><EchoResponse><Value>pong</Value></EchoResponse>`)
+
+	var response echoResponse
+	err := c.call(context.Background(), "usps.Echo", "Echo", &echoRequest{UserId: c.UserId, Value: "ping"}, &response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Value != "pong" {
+		t.Errorf("Value = %q, want pong", response.Value)
+	}
+
+	if len(*requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(*requests))
+	}
+	r := (*requests)[0]
+	if r.API != "Echo" {
+		t.Errorf("API = %q, want Echo", r.API)
+	}
+	want := xml.Header + `<EchoRequest USERID="TESTUSER"><Value>ping</Value></EchoRequest>`
+	if r.XML != want {
+		t.Errorf("XML = %s\nwant  %s", r.XML, want)
+	}
+}
+
+func TestSendUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := &Client{UserId: "TESTUSER", BaseURL: server.URL}
+	_, err := c.send(context.Background(), "Echo", []byte("<EchoRequest/>"))
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("got %v, want an unexpected HTTP status error", err)
+	}
+}
+
+func TestCallBadXML(t *testing.T) {
+	c, _ := testClient(t, `<EchoResponse><Value>`)
+
+	var response echoResponse
+	err := c.call(context.Background(), "usps.Echo", "Echo", &echoRequest{}, &response)
+	if err == nil || !strings.HasPrefix(err.Error(), "usps.Echo: XML unmarshalling failed") {
+		t.Errorf("got %v, want an unmarshalling error", err)
+	}
+}
+
+func TestCallContextDeadline(t *testing.T) {
+	// The handler holds the reply back until the test is over.
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := &Client{UserId: "TESTUSER", BaseURL: server.URL}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var response echoResponse
+	err := c.call(ctx, "usps.Echo", "Echo", &echoRequest{}, &response)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestTraceRedactsUserID(t *testing.T) {
+	// A short, numeric USERID also appears in the declaration, the ZIP codes
+	// and the rates, none of which may be touched.
+	reply := `<?xml version="1.0" encoding="UTF-8"?><EchoResponse><Value>11.10</Value></EchoResponse>`
+	c, requests := testClient(t, reply)
+	c.UserId = "1"
+
+	type traced struct {
+		dir  Direction
+		data string
+	}
+	var got []traced
+	c.Trace = func(dir Direction, data []byte) {
+		got = append(got, traced{dir, string(data)})
+	}
+
+	var response echoResponse
+	if err := c.call(context.Background(), "usps.Echo", "Echo", &echoRequest{UserId: c.UserId, Value: "21093-1001"}, &response); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("Trace called %d times, want 2", len(got))
+	}
+
+	want := xml.Header + `<EchoRequest USERID="REDACTED"><Value>21093-1001</Value></EchoRequest>`
+	if got[0].dir != TraceRequest || got[0].data != want {
+		t.Errorf("traced request %v %s\nwant request %s", got[0].dir, got[0].data, want)
+	}
+	if got[1].dir != TraceResponse || got[1].data != reply {
+		t.Errorf("traced response %v %s\nwant response %s", got[1].dir, got[1].data, reply)
+	}
+
+	// The USERID must still reach USPS.
+	if sentXML := (*requests)[0].XML; !strings.Contains(sentXML, `USERID="1"`) {
+		t.Errorf("sent %s, want the real USERID", sentXML)
+	}
+}
+
+func TestRedactUserID(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`<RateV4Request USERID="1"><Revision>2</Revision></RateV4Request>`,
+			`<RateV4Request USERID="REDACTED"><Revision>2</Revision></RateV4Request>`},
+		{"<?xml version=\"1.0\"?>\n<TrackFieldRequest USERID=\"ABC\" PASSWORD=\"\"><TrackID ID=\"ABC\"/></TrackFieldRequest>",
+			"<?xml version=\"1.0\"?>\n<TrackFieldRequest USERID=\"REDACTED\" PASSWORD=\"\"><TrackID ID=\"ABC\"/></TrackFieldRequest>"},
+		// Only the root element's attribute is a USERID.
+		{`<Request><Note USERID="1"/></Request>`, `<Request><Note USERID="1"/></Request>`},
+	}
+
+	for _, tt := range tests {
+		if got := string(redactUserID([]byte(tt.in))); got != tt.want {
+			t.Errorf("redactUserID(%s)\n= %s\nwant %s", tt.in, got, tt.want)
+		}
+	}
+}
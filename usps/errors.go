@@ -0,0 +1,113 @@
+package usps
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// APIError is an <Error> document returned by USPS, either in place of the
+// whole response or inside a single batched element such as a <Package>.
+type APIError struct {
+	Number      string
+	Source      string
+	Description string
+
+	// ID is the ID attribute of the batched element the error was reported
+	// against, e.g. the package ID. Empty for top-level errors.
+	ID string `xml:"-"`
+}
+
+func (e *APIError) Error() string {
+	msg := "usps: " + strings.TrimSpace(e.Description) + " (" + e.Number + ")"
+	if e.ID != "" {
+		msg = "usps: ID " + e.ID + ": " + msg[len("usps: "):]
+	}
+	return msg
+}
+
+// Errors collects the per-element errors of a batched request whose other
+// elements succeeded. Use errors.As to get at a single *APIError.
+type Errors []*APIError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Returns an Errors for errs, or nil if there are none. This keeps callers
+// from returning a non-nil error interface holding an empty slice.
+func (e Errors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ValidationError is a single problem found with a package before it was
+// sent to USPS.
+type ValidationError struct {
+	Field   string
+	Problem string
+}
+
+func (e *ValidationError) Error() string {
+	return "usps: " + e.Field + " " + e.Problem
+}
+
+// ValidationErrors lists every problem found with a package.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+func (e ValidationErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Unmarshals a USPS response into v, turning a top-level <Error> document
+// into an *APIError.
+func decodeResponse(rawxml []byte, v interface{}) error {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(rawxml, &root); err != nil {
+		return err
+	}
+
+	if root.XMLName.Local == "Error" {
+		apiErr := new(APIError)
+		if err := xml.Unmarshal(rawxml, apiErr); err != nil {
+			return err
+		}
+		return apiErr
+	}
+
+	return xml.Unmarshal(rawxml, v)
+}
@@ -0,0 +1,82 @@
+package usps
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func testRateRequest() *RateRequest {
+	return &RateRequest{Packages: []Package{
+		{Service: ServicePriority, Container: ContainerVariable, ZipFrom: "20770", ZipTo: "54901", Weight: 10},
+		{Service: ServicePriority, Container: ContainerVariable, ZipFrom: "20770", ZipTo: "00000", Weight: 10},
+	}}
+}
+
+func TestTopLevelAPIError(t *testing.T) {
+	c, _ := testClient(t, `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Number>80040B1A</Number><Description>Authorization failure.  Perhaps username and/or password is incorrect.</Description><Source>USPSCOM::DoAuth</Source></Error>`)
+
+	estimates, err := c.Shop(context.Background(), testRateRequest())
+	if estimates != nil {
+		t.Errorf("got %+v, want no estimates", estimates)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %v, want an *APIError", err)
+	}
+	want := APIError{
+		Number:      "80040B1A",
+		Source:      "USPSCOM::DoAuth",
+		Description: "Authorization failure.  Perhaps username and/or password is incorrect.",
+	}
+	if *apiErr != want {
+		t.Errorf("got %+v, want %+v", *apiErr, want)
+	}
+}
+
+func TestPackageErrors(t *testing.T) {
+	c, _ := testClient(t, `<?xml version="1.0" encoding="UTF-8"?>
+<RateV4Response>`+
+		`<Package ID="0"><ZipOrigination>20770</ZipOrigination><ZipDestination>54901</ZipDestination><Postage CLASSID="1"><MailService>Priority Mail 2-Day&amp;lt;sup&amp;gt;&amp;#8482;&amp;lt;/sup&amp;gt;</MailService><Rate>9.45</Rate></Postage></Package>`+
+		`<Package ID="1"><Error><Number>-2147219497</Number><Source>DomesticRatesV4;clsRateV4.ValidateDestinationZip;RateEngineV4.ProcessRequest</Source><Description>Please enter a valid ZIP Code for the recipient.</Description></Error></Package>`+
+		`</RateV4Response>`)
+
+	estimates, err := c.Shop(context.Background(), testRateRequest())
+
+	if len(estimates) != 1 || estimates[0].PackageID != "0" || estimates[0].Cost != 9.45 {
+		t.Errorf("got %+v, want the estimate for package 0", estimates)
+	}
+
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("got %v, want Errors", err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("got %d errors, want 1", len(errs))
+	}
+	if errs[0].ID != "1" || errs[0].Number != "-2147219497" {
+		t.Errorf("got %+v, want the error for package 1", errs[0])
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.ID != "1" {
+		t.Errorf("errors.As reached %+v, want the package 1 *APIError", apiErr)
+	}
+	if want := "usps: ID 1: Please enter a valid ZIP Code for the recipient. (-2147219497)"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestErrorsOrNil(t *testing.T) {
+	var errs Errors
+	if err := errs.orNil(); err != nil {
+		t.Errorf("empty Errors became %#v, want nil", err)
+	}
+
+	var invalid ValidationErrors
+	if err := invalid.orNil(); err != nil {
+		t.Errorf("empty ValidationErrors became %#v, want nil", err)
+	}
+}
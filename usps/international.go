@@ -0,0 +1,175 @@
+package usps
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+/*
+MAIL TYPE:
+ALL
+PACKAGE
+ENVELOPE
+LARGEENVELOPE
+*/
+
+type MailType string
+
+const (
+	MailTypeAll           MailType = "ALL"
+	MailTypePackage       MailType = "PACKAGE"
+	MailTypeEnvelope      MailType = "ENVELOPE"
+	MailTypeLargeEnvelope MailType = "LARGEENVELOPE"
+)
+
+// USPS service IDs of the international mail classes, as found in
+// IntlEstimate.ServiceID. Flat rate variants have IDs of their own.
+const (
+	IntlServiceExpress           = "1"  // Priority Mail Express International
+	IntlServicePriority          = "2"  // Priority Mail International
+	IntlServiceFirstClassPackage = "15" // First-Class Package International Service
+)
+
+type IntlPackage struct {
+	MailType  MailType
+	Container Container // RECTANGULAR or NONRECTANGULAR for LARGE packages.
+
+	// Country is the USPS country name, e.g. "Canada" or
+	// "Great Britain and Northern Ireland".
+	Country   string
+	OriginZip string
+
+	// ValueOfContents is in US dollars.
+	ValueOfContents float64
+	Machinable      bool
+
+	// Commercial asks for commercial prices in addition to retail ones.
+	Commercial bool
+
+	// Weight:
+	// Units are ounces.
+	Weight float64
+
+	// Width/Length/Height/Girth:
+	// Units are inches.
+	// Girth is only needed for NONRECTANGULAR packages. When left at zero it
+	// is measured around the two shortest sides.
+	Width  float64
+	Height float64
+	Length float64
+	Girth  float64
+}
+
+type IntlEstimate struct {
+	Description    string // Verbal description of the estimate.
+	ServiceID      string
+	Cost           float64
+	CommercialCost float64
+	Commitment     string // e.g. "6 - 10 business days"
+
+	MaxDimensions string
+	MaxWeight     float64 // Pounds.
+
+	PackageID string // ID of the package in the request this estimate is for.
+
+	// Country-wide notes, the same for every estimate of a package.
+	Prohibitions string
+	Restrictions string
+	Observations string
+}
+
+// ShopIntl prices every international mail class USPS offers for each
+// package. Packages that failed are reported in an Errors alongside the
+// estimates of the ones that succeeded.
+func (c *Client) ShopIntl(ctx context.Context, packages ...IntlPackage) ([]IntlEstimate, error) {
+	request := &IntlRateV2Request{
+		UserId:   c.UserId,
+		Revision: intlRateV2Revision,
+		Packages: make([]IntlRateV2RequestPackage, len(packages)),
+	}
+
+	for i, p := range packages {
+		if p.Country == "" {
+			return nil, errors.New("usps.ShopIntl: Country is required")
+		}
+		request.Packages[i] = p.request(strconv.Itoa(i))
+	}
+
+	var response IntlRateV2Response
+	if err := c.call(ctx, "usps.ShopIntl", "IntlRateV2", request, &response); err != nil {
+		return nil, err
+	}
+
+	var estimates []IntlEstimate
+	var errs Errors
+	for _, p := range response.Packages {
+		if p.Error != nil {
+			p.Error.ID = p.ID
+			errs = append(errs, p.Error)
+			continue
+		}
+
+		for _, s := range p.Service {
+			estimates = append(estimates, IntlEstimate{
+				Description:    readableMailService(s.SvcDescription),
+				ServiceID:      s.ID,
+				Cost:           s.Postage,
+				CommercialCost: s.CommercialPostage,
+				Commitment:     s.SvcCommitments,
+				MaxDimensions:  s.MaxDimensions,
+				MaxWeight:      s.MaxWeight,
+				PackageID:      p.ID,
+				Prohibitions:   p.Prohibitions,
+				Restrictions:   p.Restrictions,
+				Observations:   p.Observations,
+			})
+		}
+	}
+
+	return estimates, errs.orNil()
+}
+
+func (p *IntlPackage) request(id string) IntlRateV2RequestPackage {
+	pounds, ounces := splitWeight(p.Weight)
+
+	mailType := p.MailType
+	if mailType == "" {
+		mailType = MailTypeAll
+	}
+
+	rp := IntlRateV2RequestPackage{
+		ID:              id,
+		Pounds:          pounds,
+		Ounces:          ounces,
+		Machinable:      p.Machinable,
+		MailType:        mailType,
+		ValueOfContents: strconv.FormatFloat(p.ValueOfContents, 'f', 2, 64),
+		Country:         p.Country,
+		Container:       p.Container,
+		Size:            "REGULAR",
+		OriginZip:       p.OriginZip,
+	}
+
+	if p.Commercial {
+		rp.CommercialFlag = "Y"
+	}
+
+	if (p.Width > 12) || (p.Height > 12) || (p.Length > 12) {
+		rp.Size = "LARGE"
+	}
+
+	if rp.Size == "LARGE" || p.Container != "" {
+		rp.Width = p.Width
+		rp.Length = p.Length
+		rp.Height = p.Height
+		if p.Container == ContainerNonrectangular {
+			rp.Girth = p.Girth
+			if rp.Girth == 0 {
+				rp.Girth = girth(p.Width, p.Height, p.Length)
+			}
+		}
+	}
+
+	return rp
+}
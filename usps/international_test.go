@@ -0,0 +1,61 @@
+package usps
+
+import (
+	"context"
+	"testing"
+)
+
+func TestShopIntlRequest(t *testing.T) {
+	c, requests := testClient(t, `<?xml version="1.0" encoding="UTF-8"?><IntlRateV2Response></IntlRateV2Response>`)
+
+	_, err := c.ShopIntl(context.Background(),
+		IntlPackage{
+			MailType:        MailTypeEnvelope,
+			Country:         "Canada",
+			OriginZip:       "20770",
+			ValueOfContents: 25,
+			Weight:          3.5,
+		},
+		// The longest side is in Height, so girth is measured around
+		// Width and Length.
+		IntlPackage{
+			MailType:        MailTypePackage,
+			Container:       ContainerNonrectangular,
+			Country:         "Great Britain and Northern Ireland",
+			OriginZip:       "20770",
+			ValueOfContents: 149.5,
+			Machinable:      true,
+			Commercial:      true,
+			Weight:          40,
+			Width:           6,
+			Height:          20,
+			Length:          8,
+		},
+		IntlPackage{
+			MailType:  MailTypePackage,
+			Container: ContainerNonrectangular,
+			Country:   "Germany",
+			Weight:    15.96,
+			Width:     14,
+			Height:    6,
+			Length:    8,
+			Girth:     26,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden(t, "intl_rate_v2.xml", []byte((*requests)[0].XML))
+}
+
+func TestShopIntlCountryRequired(t *testing.T) {
+	c, requests := testClient(t, "")
+
+	if _, err := c.ShopIntl(context.Background(), IntlPackage{Weight: 10}); err == nil {
+		t.Error("got no error for a package without a Country")
+	}
+	if len(*requests) != 0 {
+		t.Errorf("sent %d requests, want none", len(*requests))
+	}
+}
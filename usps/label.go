@@ -0,0 +1,267 @@
+package usps
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type LabelImageType string
+
+const (
+	LabelImagePDF LabelImageType = "PDF"
+	LabelImageTIF LabelImageType = "TIF"
+)
+
+// The eVS service type for each Service that labels can be bought for.
+var labelServiceTypes = map[Service]string{
+	ServicePriority:   "PRIORITY",
+	ServiceFirstClass: "FIRST CLASS",
+	ServiceParcel:     "PARCEL SELECT GROUND",
+}
+
+type CancelStatus int
+
+const (
+	// USPS did not say what happened, e.g. because the request failed.
+	CancelStatusUnknown CancelStatus = iota
+
+	// The label was voided and its postage will be refunded.
+	CancelStatusCancelled
+
+	// USPS refused to void the label; Reason says why.
+	CancelStatusNotCancelled
+
+	// The package has already entered the mail stream, so the postage has
+	// been used and cannot be refunded.
+	CancelStatusAlreadyScanned
+)
+
+func (s CancelStatus) String() string {
+	switch s {
+	case CancelStatusCancelled:
+		return "Cancelled"
+	case CancelStatusNotCancelled:
+		return "Not Cancelled"
+	case CancelStatusAlreadyScanned:
+		return "Already Scanned"
+	}
+	return "Unknown"
+}
+
+// Phrases in an eVSCancel Reason saying the package is already in the mail
+// stream. USPS words this differently from one release to the next, so the
+// match is on phrases rather than whole reasons.
+var alreadyScannedPhrases = []string{
+	"already scanned",
+	"been scanned",
+	"mail stream",
+	"mailstream",
+}
+
+// Reports whether reason says the label could not be cancelled because its
+// package has been scanned.
+func alreadyScanned(reason string) bool {
+	reason = strings.ToLower(strings.Join(strings.Fields(reason), " "))
+	for _, phrase := range alreadyScannedPhrases {
+		if strings.Contains(reason, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+type CancelResult struct {
+	TrackingNumber string
+	Status         CancelStatus
+	Reason         string // USPS's explanation, e.g. "Order Cancelled Successfully".
+}
+
+type LabelRequest struct {
+	From Address
+	To   Address
+
+	// Package.Service must be ServicePriority, ServiceFirstClass or
+	// ServiceParcel. ZipFrom and ZipTo are ignored in favour of the addresses.
+	Package Package
+
+	// ImageType defaults to LabelImagePDF.
+	ImageType LabelImageType
+
+	// CustomerRefNo is printed on the label, e.g. an order number.
+	CustomerRefNo string
+
+	// HoldForManifest keeps the label off the USPS manifest until it is put
+	// on a SCAN form with SCANForms. Leave it unset for labels that will be
+	// scanned package by package.
+	HoldForManifest bool
+}
+
+type Label struct {
+	TrackingNumber string
+	Postage        float64
+	Zone           string
+
+	ImageType LabelImageType
+	Image     []byte
+
+	// Commitment is the delivery commitment, e.g. "2-Day", and
+	// ScheduledDeliveryDate the expected delivery date as YYYY-MM-DD.
+	Commitment            string
+	ScheduledDeliveryDate string
+}
+
+// Label buys postage for a domestic package through eVS and returns the
+// printable label.
+func (c *Client) Label(ctx context.Context, req *LabelRequest) (*Label, error) {
+	request, err := c.labelRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("usps.Label: %w", err)
+	}
+
+	var response EVSResponse
+	if err := c.call(ctx, "usps.Label", "eVS", request, &response); err != nil {
+		return nil, err
+	}
+
+	image, err := base64.StdEncoding.DecodeString(response.LabelImage)
+	if err != nil {
+		return nil, fmt.Errorf("usps.Label: Unable to decode label image:\n%w", err)
+	}
+
+	return &Label{
+		TrackingNumber:        response.BarcodeNumber,
+		Postage:               response.Postage,
+		Zone:                  response.Zone,
+		ImageType:             request.ImageType,
+		Image:                 image,
+		Commitment:            response.Commitment.CommitmentName,
+		ScheduledDeliveryDate: response.Commitment.ScheduledDeliveryDate,
+	}, nil
+}
+
+// CancelLabel voids an unused eVS label. USPS refunds the postage of labels
+// that are cancelled before they are scanned.
+func (c *Client) CancelLabel(ctx context.Context, trackingNumber string) (CancelResult, error) {
+	result := CancelResult{TrackingNumber: trackingNumber}
+
+	request := &EVSCancelRequest{UserId: c.UserId, BarcodeNumber: trackingNumber}
+
+	var response EVSCancelResponse
+	if err := c.call(ctx, "usps.CancelLabel", "eVSCancel", request, &response); err != nil {
+		return result, err
+	}
+
+	result.Reason = response.Reason
+	switch {
+	case strings.EqualFold(response.Status, "Cancelled"):
+		result.Status = CancelStatusCancelled
+	case alreadyScanned(response.Reason):
+		result.Status = CancelStatusAlreadyScanned
+	default:
+		result.Status = CancelStatusNotCancelled
+	}
+
+	return result, nil
+}
+
+func (c *Client) labelRequest(req *LabelRequest) (*EVSRequest, error) {
+	p, err := newLabelPackage(req.Package, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	imageType := req.ImageType
+	if imageType == "" {
+		imageType = LabelImagePDF
+	}
+
+	request := &EVSRequest{
+		UserId:         c.UserId,
+		Revision:       "1",
+		FromName:       req.From.Name,
+		FromFirm:       req.From.Firm,
+		FromAddress1:   req.From.Address2,
+		FromAddress2:   req.From.Address1,
+		FromCity:       req.From.City,
+		FromState:      req.From.State,
+		FromZip5:       req.From.Zip5,
+		FromZip4:       req.From.Zip4,
+		FromPhone:      req.From.Phone,
+		ToName:         req.To.Name,
+		ToFirm:         req.To.Firm,
+		ToAddress1:     req.To.Address2,
+		ToAddress2:     req.To.Address1,
+		ToCity:         req.To.City,
+		ToState:        req.To.State,
+		ToZip5:         req.To.Zip5,
+		ToZip4:         req.To.Zip4,
+		ToPhone:        req.To.Phone,
+		WeightInOunces: p.Weight,
+		ServiceType:    p.ServiceType,
+		Container:      p.Container,
+		Width:          p.Width,
+		Length:         p.Length,
+		Height:         p.Height,
+		Girth:          p.Girth,
+		Machinable:     true,
+		CustomerRefNo:  req.CustomerRefNo,
+		ImageType:      imageType,
+	}
+	request.ImageParameters.ImageParameter = "4X6LABEL"
+	request.HoldForManifest = "N"
+	if req.HoldForManifest {
+		request.HoldForManifest = "Y"
+	}
+
+	return request, nil
+}
+
+// labelPackage is a Package checked and measured for an eVS or returns label.
+type labelPackage struct {
+	Weight      float64
+	ServiceType string
+	Container   Container
+
+	// The dimensions are only sent for large packages, and Girth only for
+	// nonrectangular ones. They are zero otherwise.
+	Width, Length, Height, Girth float64
+}
+
+// Validates p as shipped from one address to another and works out what a
+// label request needs to say about it. The ZipFrom and ZipTo of p are
+// replaced by those of the addresses.
+func newLabelPackage(p Package, from, to Address) (*labelPackage, error) {
+	serviceType, ok := labelServiceTypes[p.Service]
+	if !ok {
+		return nil, errors.New("Labels are not available for service " + string(p.Service))
+	}
+
+	p.ZipFrom = from.Zip5
+	p.ZipTo = to.Zip5
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	lp := &labelPackage{
+		Weight:      p.Weight,
+		ServiceType: serviceType,
+		Container:   p.Container,
+	}
+	if lp.Container == "" {
+		lp.Container = ContainerVariable
+	}
+
+	if p.IsLarge {
+		lp.Width = p.Width
+		lp.Length = p.Length
+		lp.Height = p.Height
+		if lp.Container == ContainerNonrectangular {
+			lp.Girth = girth(p.Width, p.Height, p.Length)
+		}
+	}
+
+	return lp, nil
+}
@@ -0,0 +1,89 @@
+package usps
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCancelLabel(t *testing.T) {
+	tests := []struct {
+		status, reason string
+		want           CancelStatus
+	}{
+		{"Cancelled", "Order Cancelled Successfully", CancelStatusCancelled},
+		{"CANCELLED", "", CancelStatusCancelled},
+		{"Not Cancelled", "Order Not Cancelled. Package already scanned", CancelStatusAlreadyScanned},
+		{"Not Cancelled", "Order Not Cancelled, the package has been scanned", CancelStatusAlreadyScanned},
+		{"Not Cancelled", "Package is already in the Mail Stream", CancelStatusAlreadyScanned},
+		{"Not Cancelled", "Label is in the mailstream and cannot be cancelled", CancelStatusAlreadyScanned},
+		{"Not Cancelled", "Order Not Cancelled. Label not found", CancelStatusNotCancelled},
+		{"Not Cancelled", "", CancelStatusNotCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			c, requests := testClient(t, `<?xml version="1.0" encoding="UTF-8"?>
+<eVSCancelResponse><BarcodeNumber>420545019205590102090000000000</BarcodeNumber><Status>`+tt.status+`</Status><Reason>`+tt.reason+`</Reason></eVSCancelResponse>`)
+
+			result, err := c.CancelLabel(context.Background(), "420545019205590102090000000000")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Status != tt.want {
+				t.Errorf("Status = %v, want %v", result.Status, tt.want)
+			}
+			if result.Reason != tt.reason {
+				t.Errorf("Reason = %q, want %q", result.Reason, tt.reason)
+			}
+
+			r := (*requests)[0]
+			if r.API != "eVSCancel" || !strings.Contains(r.XML, "<BarcodeNumber>420545019205590102090000000000</BarcodeNumber>") {
+				t.Errorf("sent %s %s", r.API, r.XML)
+			}
+		})
+	}
+}
+
+func TestCancelLabelError(t *testing.T) {
+	c, _ := testClient(t, `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Number>-2147219401</Number><Description>Barcode not found.</Description></Error>`)
+
+	result, err := c.CancelLabel(context.Background(), "420545019205590102090000000000")
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	if result.Status != CancelStatusUnknown {
+		t.Errorf("Status = %v on error, want %v", result.Status, CancelStatusUnknown)
+	}
+}
+
+func TestLabelHoldForManifest(t *testing.T) {
+	reply := `<?xml version="1.0" encoding="UTF-8"?>
+<eVSResponse><BarcodeNumber>420545019205590102090000000000</BarcodeNumber><LabelImage>JVBERi0=</LabelImage><Postage>9.45</Postage><Zone>4</Zone></eVSResponse>`
+
+	for _, hold := range []bool{false, true} {
+		c, requests := testClient(t, reply)
+
+		label, err := c.Label(context.Background(), &LabelRequest{
+			From:            Address{Name: "Shipper", Address1: "1 Main St", City: "Greenbelt", State: "MD", Zip5: "20770"},
+			To:              Address{Name: "Customer", Address1: "2 Elm St", City: "Oshkosh", State: "WI", Zip5: "54901"},
+			Package:         Package{Service: ServicePriority, Weight: 10},
+			HoldForManifest: hold,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if label.TrackingNumber != "420545019205590102090000000000" || string(label.Image) != "%PDF-" {
+			t.Errorf("got label %s %q", label.TrackingNumber, label.Image)
+		}
+
+		want := "<HoldForManifest>N</HoldForManifest>"
+		if hold {
+			want = "<HoldForManifest>Y</HoldForManifest>"
+		}
+		if sent := (*requests)[0].XML; !strings.Contains(sent, want) {
+			t.Errorf("HoldForManifest %v: request is missing %s:\n%s", hold, want, sent)
+		}
+	}
+}
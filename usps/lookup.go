@@ -0,0 +1,95 @@
+package usps
+
+import (
+	"context"
+	"strconv"
+)
+
+// USPS accepts at most this many addresses or ZIP codes per lookup request.
+const maxLookupBatch = 5
+
+// ZipCodeLookup fills in the ZIP code and ZIP+4 of each address. The result
+// has one entry per input address, in the same order. Addresses USPS could
+// not find are left zero-valued and reported in an Errors whose IDs are the
+// indexes of the failed addresses.
+func (c *Client) ZipCodeLookup(ctx context.Context, addresses ...Address) ([]Address, error) {
+	results := make([]Address, len(addresses))
+	var errs Errors
+
+	for start := 0; start < len(addresses); start += maxLookupBatch {
+		end := min(start+maxLookupBatch, len(addresses))
+
+		request := &ZipCodeLookupRequest{UserId: c.UserId}
+		for i := start; i < end; i++ {
+			request.Addresses = append(request.Addresses, addresses[i].request(strconv.Itoa(i)))
+		}
+
+		var response ZipCodeLookupResponse
+		if err := c.call(ctx, "usps.ZipCodeLookup", "ZipCodeLookup", request, &response); err != nil {
+			return nil, err
+		}
+
+		for _, r := range response.Addresses {
+			i, ok := batchIndex(r.ID, start, end)
+			if !ok {
+				continue
+			}
+
+			if r.Error != nil {
+				r.Error.ID = r.ID
+				errs = append(errs, r.Error)
+				continue
+			}
+			results[i] = r.address()
+		}
+	}
+
+	return results, errs.orNil()
+}
+
+// CityStateLookup returns the city and state of each 5-digit ZIP code, with
+// Zip5 set. Results and errors follow the same rules as ZipCodeLookup.
+func (c *Client) CityStateLookup(ctx context.Context, zips ...string) ([]Address, error) {
+	results := make([]Address, len(zips))
+	var errs Errors
+
+	for start := 0; start < len(zips); start += maxLookupBatch {
+		end := min(start+maxLookupBatch, len(zips))
+
+		request := &CityStateLookupRequest{UserId: c.UserId}
+		for i := start; i < end; i++ {
+			request.ZipCodes = append(request.ZipCodes, RequestZipCode{ID: strconv.Itoa(i), Zip5: zips[i]})
+		}
+
+		var response CityStateLookupResponse
+		if err := c.call(ctx, "usps.CityStateLookup", "CityStateLookup", request, &response); err != nil {
+			return nil, err
+		}
+
+		for _, r := range response.ZipCodes {
+			i, ok := batchIndex(r.ID, start, end)
+			if !ok {
+				continue
+			}
+
+			if r.Error != nil {
+				r.Error.ID = r.ID
+				errs = append(errs, r.Error)
+				continue
+			}
+			results[i] = Address{City: r.City, State: r.State, Zip5: r.Zip5}
+		}
+	}
+
+	return results, errs.orNil()
+}
+
+// Maps a response ID back to the index of the input it was sent for. IDs
+// outside the batch are ignored rather than trusted.
+func batchIndex(id string, start, end int) (int, bool) {
+	i, err := strconv.Atoi(id)
+	if err != nil || i < start || i >= end {
+		return 0, false
+	}
+	return i, true
+}
@@ -0,0 +1,227 @@
+package usps
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"math"
+	"strings"
+)
+
+type PickupService string
+
+const (
+	PickupPriorityMailExpress PickupService = "PriorityMailExpress"
+	PickupPriorityMail        PickupService = "PriorityMail"
+	PickupFirstClass          PickupService = "FirstClass"
+	PickupReturns             PickupService = "Returns"
+	PickupInternational       PickupService = "International"
+	PickupOtherPackages       PickupService = "OtherPackages"
+)
+
+/*
+PACKAGE LOCATION:
+Front Door
+Back Door
+Side Door
+Knock on Door/Ring Bell
+Mail Room
+Office
+Reception
+In/At Mailbox
+Other
+
+Note: SpecialInstructions are required when the location is Other.
+*/
+
+type PickupLocation string
+
+const (
+	PickupFrontDoor PickupLocation = "Front Door"
+	PickupBackDoor  PickupLocation = "Back Door"
+	PickupSideDoor  PickupLocation = "Side Door"
+	PickupKnock     PickupLocation = "Knock on Door/Ring Bell"
+	PickupMailRoom  PickupLocation = "Mail Room"
+	PickupOffice    PickupLocation = "Office"
+	PickupReception PickupLocation = "Reception"
+	PickupMailbox   PickupLocation = "In/At Mailbox"
+	PickupOther     PickupLocation = "Other"
+)
+
+type PickupPackage struct {
+	Service PickupService
+	Count   int
+}
+
+type PickupRequest struct {
+	// Address is where the carrier collects the packages. Name and Phone are
+	// required; Name is split into first and last name at the first space.
+	Address Address
+
+	Packages []PickupPackage
+
+	// EstimatedWeight is the total weight of all packages, in ounces.
+	EstimatedWeight float64
+
+	Location            PickupLocation
+	SpecialInstructions string
+
+	// Email, if set, receives the USPS confirmation.
+	Email string
+}
+
+type Pickup struct {
+	ConfirmationNumber string
+	DayOfWeek          string
+	Date               string // As sent by USPS, e.g. "1/21/2016".
+	CarrierRoute       string
+	Packages           []PickupPackage
+	Location           PickupLocation
+}
+
+type PickupAvailability struct {
+	DayOfWeek    string
+	Date         string // As sent by USPS, e.g. "1/21/2016".
+	CarrierRoute string
+}
+
+// PickupAvailability returns the next day a carrier can collect packages at
+// a.
+func (c *Client) PickupAvailability(ctx context.Context, a Address) (PickupAvailability, error) {
+	var availability PickupAvailability
+
+	request := &CarrierPickupAvailabilityRequest{UserId: c.UserId, PickupAddress: a.pickup()}
+
+	var response CarrierPickupAvailabilityResponse
+	if err := c.call(ctx, "usps.PickupAvailability", "CarrierPickupAvailability", request, &response); err != nil {
+		return availability, err
+	}
+
+	availability.DayOfWeek = response.DayOfWeek
+	availability.Date = response.Date
+	availability.CarrierRoute = response.CarrierRoute
+
+	return availability, nil
+}
+
+// SchedulePickup books a carrier pickup for the next available day.
+func (c *Client) SchedulePickup(ctx context.Context, req *PickupRequest) (*Pickup, error) {
+	return c.pickup(ctx, "usps.SchedulePickup", "CarrierPickupSchedule", req, "")
+}
+
+// ChangePickup replaces the details of the pickup with the given
+// confirmation number.
+func (c *Client) ChangePickup(ctx context.Context, confirmation string, req *PickupRequest) (*Pickup, error) {
+	if confirmation == "" {
+		return nil, errors.New("usps.ChangePickup: A confirmation number is required")
+	}
+	return c.pickup(ctx, "usps.ChangePickup", "CarrierPickupChange", req, confirmation)
+}
+
+// CancelPickup cancels a scheduled pickup. The address must be the one the
+// pickup was booked for. The USPS status message is returned.
+func (c *Client) CancelPickup(ctx context.Context, a Address, confirmation string) (string, error) {
+	request := &CarrierPickupLookupRequest{
+		XMLName:            xml.Name{Local: "CarrierPickupCancelRequest"},
+		UserId:             c.UserId,
+		PickupAddress:      a.pickup(),
+		ConfirmationNumber: confirmation,
+	}
+
+	var response CarrierPickupCancelResponse
+	if err := c.call(ctx, "usps.CancelPickup", "CarrierPickupCancel", request, &response); err != nil {
+		return "", err
+	}
+
+	return response.Status, nil
+}
+
+// PickupInquiry looks up a scheduled pickup. The address must be the one the
+// pickup was booked for.
+func (c *Client) PickupInquiry(ctx context.Context, a Address, confirmation string) (*Pickup, error) {
+	request := &CarrierPickupLookupRequest{
+		XMLName:            xml.Name{Local: "CarrierPickupInquiryRequest"},
+		UserId:             c.UserId,
+		PickupAddress:      a.pickup(),
+		ConfirmationNumber: confirmation,
+	}
+
+	var response CarrierPickupResponse
+	if err := c.call(ctx, "usps.PickupInquiry", "CarrierPickupInquiry", request, &response); err != nil {
+		return nil, err
+	}
+
+	return response.pickup(), nil
+}
+
+// Schedule and Change take the same document, only the root element and the
+// confirmation number differ.
+func (c *Client) pickup(ctx context.Context, caller, api string, req *PickupRequest, confirmation string) (*Pickup, error) {
+	if req.Address.Name == "" || req.Address.Phone == "" {
+		return nil, errors.New(caller + ": Address.Name and Address.Phone are required")
+	}
+
+	if len(req.Packages) == 0 {
+		return nil, errors.New(caller + ": At least one package is required")
+	}
+
+	if req.Location == PickupOther && req.SpecialInstructions == "" {
+		return nil, errors.New(caller + ": SpecialInstructions are required when the location is Other")
+	}
+
+	first, last, _ := strings.Cut(strings.TrimSpace(req.Address.Name), " ")
+
+	request := &CarrierPickupRequest{
+		XMLName:             xml.Name{Local: api + "Request"},
+		UserId:              c.UserId,
+		FirstName:           first,
+		LastName:            strings.TrimSpace(last),
+		PickupAddress:       req.Address.pickup(),
+		Phone:               req.Address.Phone,
+		EstimatedWeight:     int(math.Ceil(req.EstimatedWeight / 16)),
+		PackageLocation:     req.Location,
+		SpecialInstructions: req.SpecialInstructions,
+		ConfirmationNumber:  confirmation,
+		EmailAddress:        req.Email,
+	}
+
+	for _, p := range req.Packages {
+		request.Packages = append(request.Packages, PickupPackageType{p.Service, p.Count})
+	}
+
+	var response CarrierPickupResponse
+	if err := c.call(ctx, caller, api, request, &response); err != nil {
+		return nil, err
+	}
+
+	return response.pickup(), nil
+}
+
+func (r *CarrierPickupResponse) pickup() *Pickup {
+	p := &Pickup{
+		ConfirmationNumber: r.ConfirmationNumber,
+		DayOfWeek:          r.DayOfWeek,
+		Date:               r.Date,
+		CarrierRoute:       r.CarrierRoute,
+		Location:           r.PackageLocation,
+	}
+
+	for _, pkg := range r.Packages {
+		p.Packages = append(p.Packages, PickupPackage{pkg.ServiceType, pkg.Count})
+	}
+
+	return p
+}
+
+func (a *Address) pickup() PickupAddress {
+	return PickupAddress{
+		FirmName:     a.Firm,
+		SuiteOrApt:   a.Address2,
+		Address2:     a.Address1,
+		Urbanization: a.Urbanization,
+		City:         a.City,
+		State:        a.State,
+		ZIP5:         a.Zip5,
+		ZIP4:         a.Zip4,
+	}
+}
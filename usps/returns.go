@@ -0,0 +1,89 @@
+package usps
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+type ReturnLabelRequest struct {
+	// Customer is where the package is returned from. Name is split into
+	// first and last name at the first space.
+	Customer Address
+
+	// Warehouse is where the package is returned to. USPS delivers returns to
+	// the address registered for the account; Name, Firm and Zip5 are printed
+	// on the label and used to route the package.
+	Warehouse Address
+
+	// Package.Service must be ServicePriority, ServiceFirstClass or
+	// ServiceParcel. ZipFrom and ZipTo are ignored in favour of the addresses.
+	Package Package
+
+	// OutboundTrackingNumber is printed on the label as the customer
+	// reference, so the return can be matched to the original shipment.
+	OutboundTrackingNumber string
+}
+
+type ReturnLabel struct {
+	TrackingNumber         string
+	OutboundTrackingNumber string
+
+	// Image is a PDF suitable for emailing to the customer.
+	Image []byte
+}
+
+// ReturnLabel creates a prepaid return label. Nothing is charged until USPS
+// scans the package, so unused labels cost nothing.
+func (c *Client) ReturnLabel(ctx context.Context, req *ReturnLabelRequest) (*ReturnLabel, error) {
+	p, err := newLabelPackage(req.Package, req.Customer, req.Warehouse)
+	if err != nil {
+		return nil, fmt.Errorf("usps.ReturnLabel: %w", err)
+	}
+
+	first, last, _ := strings.Cut(strings.TrimSpace(req.Customer.Name), " ")
+
+	request := &USPSReturnsLabelRequest{
+		UserId:               c.UserId,
+		CustomerFirstName:    first,
+		CustomerLastName:     strings.TrimSpace(last),
+		CustomerFirm:         req.Customer.Firm,
+		CustomerAddress1:     req.Customer.Address2,
+		CustomerAddress2:     req.Customer.Address1,
+		CustomerUrbanization: req.Customer.Urbanization,
+		CustomerCity:         req.Customer.City,
+		CustomerState:        req.Customer.State,
+		CustomerZip5:         req.Customer.Zip5,
+		CustomerZip4:         req.Customer.Zip4,
+		POZipCode:            req.Warehouse.Zip5,
+		RetailerATTN:         req.Warehouse.Name,
+		RetailerFirm:         req.Warehouse.Firm,
+		WeightInOunces:       p.Weight,
+		ServiceType:          p.ServiceType,
+		Width:                p.Width,
+		Length:               p.Length,
+		Height:               p.Height,
+		Girth:                p.Girth,
+		Machinable:           true,
+		CustomerRefNo:        req.OutboundTrackingNumber,
+		PrintCustomerRefNo:   req.OutboundTrackingNumber != "",
+	}
+	request.ImageParameters.ImageType = LabelImagePDF
+
+	var response USPSReturnsLabelResponse
+	if err := c.call(ctx, "usps.ReturnLabel", "USPSReturnsLabel", request, &response); err != nil {
+		return nil, err
+	}
+
+	image, err := base64.StdEncoding.DecodeString(response.ReturnLabel)
+	if err != nil {
+		return nil, fmt.Errorf("usps.ReturnLabel: Unable to decode label image:\n%w", err)
+	}
+
+	return &ReturnLabel{
+		TrackingNumber:         response.TrackingNumber,
+		OutboundTrackingNumber: req.OutboundTrackingNumber,
+		Image:                  image,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package usps
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// USPS accepts at most this many packages on a single SCAN form. Longer lists
+// are split across several forms.
+const MaxSCANPackages = 999
+
+// SCANForm is a PS Form 5630 Shipment Confirmation Acceptance Notice covering
+// a set of labels. The carrier scans it once instead of every package.
+type SCANForm struct {
+	Number          string // The electronic file number.
+	Image           []byte // PDF.
+	TrackingNumbers []string
+}
+
+// USPS reports labels that are already on another SCAN form under more than
+// one error number, and does not publish the list. Errors are recognised by
+// the numbers seen so far, or failing that by their description.
+var (
+	alreadyManifestedNumbers = map[string]bool{
+		"-2147219080": true,
+		"-2147219081": true,
+	}
+	alreadyManifestedPhrases = []string{
+		"already manifested",
+		"already been manifested",
+		"already on a scan form",
+	}
+)
+
+// Reports whether a SCAN error says some labels are already manifested.
+func alreadyManifested(apiErr *APIError) bool {
+	if alreadyManifestedNumbers[strings.TrimSpace(apiErr.Number)] {
+		return true
+	}
+
+	description := strings.ToLower(strings.Join(strings.Fields(apiErr.Description), " "))
+	for _, phrase := range alreadyManifestedPhrases {
+		if strings.Contains(description, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// AlreadyManifestedError is returned when USPS refuses a SCAN form because
+// some of its labels are already on another one.
+type AlreadyManifestedError struct {
+	// TrackingNumbers lists the labels named in the USPS error. It is empty
+	// when USPS did not say which labels were affected.
+	TrackingNumbers []string
+	Err             *APIError
+}
+
+func (e *AlreadyManifestedError) Error() string {
+	if len(e.TrackingNumbers) == 0 {
+		return "usps.SCANForms: Labels already manifested: " + e.Err.Description
+	}
+	return "usps.SCANForms: Labels already manifested: " + strings.Join(e.TrackingNumbers, ", ")
+}
+
+func (e *AlreadyManifestedError) Unwrap() error {
+	return e.Err
+}
+
+// SCANForms creates the end-of-day SCAN forms for the given labels, which
+// must all have been bought from the from address with HoldForManifest set.
+// When a form fails, the forms created before it are returned along with the
+// error.
+func (c *Client) SCANForms(ctx context.Context, from Address, trackingNumbers []string, mailDate time.Time) ([]SCANForm, error) {
+	seen := make(map[string]bool, len(trackingNumbers))
+	for _, number := range trackingNumbers {
+		if seen[number] {
+			return nil, errors.New("usps.SCANForms: Tracking number " + number + " is listed twice")
+		}
+		seen[number] = true
+	}
+
+	var forms []SCANForm
+	for start := 0; start < len(trackingNumbers); start += MaxSCANPackages {
+		end := min(start+MaxSCANPackages, len(trackingNumbers))
+
+		form, err := c.scanForm(ctx, from, trackingNumbers[start:end], mailDate)
+		if err != nil {
+			return forms, err
+		}
+		forms = append(forms, form)
+	}
+
+	return forms, nil
+}
+
+func (c *Client) scanForm(ctx context.Context, from Address, trackingNumbers []string, mailDate time.Time) (SCANForm, error) {
+	form := SCANForm{TrackingNumbers: trackingNumbers}
+
+	request := &SCANRequest{
+		UserId:        c.UserId,
+		FromName:      from.Name,
+		FromFirm:      from.Firm,
+		FromAddress1:  from.Address2,
+		FromAddress2:  from.Address1,
+		FromCity:      from.City,
+		FromState:     from.State,
+		FromZip5:      from.Zip5,
+		FromZip4:      from.Zip4,
+		MailDate:      mailDate.Format("20060102"),
+		MailTime:      mailDate.Format("150405"),
+		EntryFacility: from.Zip5,
+		ImageType:     LabelImagePDF,
+	}
+	request.Option.Form = "5630"
+	for _, number := range trackingNumbers {
+		request.Shipment.PackageDetail = append(request.Shipment.PackageDetail, SCANPackageDetail{number})
+	}
+
+	var response SCANResponse
+	if err := c.call(ctx, "usps.SCANForms", "SCAN", request, &response); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && alreadyManifested(apiErr) {
+			return form, manifestedError(apiErr, trackingNumbers)
+		}
+		return form, err
+	}
+
+	image, err := base64.StdEncoding.DecodeString(response.SCANFormImage)
+	if err != nil {
+		return form, fmt.Errorf("usps.SCANForms: Unable to decode SCAN form image:\n%w", err)
+	}
+
+	form.Number = response.SCANFormNumber
+	form.Image = image
+
+	return form, nil
+}
+
+// Picks out the tracking numbers USPS named in its error description.
+func manifestedError(apiErr *APIError, trackingNumbers []string) *AlreadyManifestedError {
+	e := &AlreadyManifestedError{Err: apiErr}
+	for _, number := range trackingNumbers {
+		if strings.Contains(apiErr.Description, number) {
+			e.TrackingNumbers = append(e.TrackingNumbers, number)
+		}
+	}
+	return e
+}
@@ -0,0 +1,128 @@
+package usps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+const scanReply = `<?xml version="1.0" encoding="UTF-8"?>
+<SCANResponse><SCANFormNumber>9275090104387000000000</SCANFormNumber><SCANFormImage>JVBERi0=</SCANFormImage></SCANResponse>`
+
+var (
+	scanFrom = Address{Name: "Shipper", Address1: "1 Main St", City: "Greenbelt", State: "MD", Zip5: "20770"}
+	scanDate = time.Date(2026, 10, 17, 16, 30, 0, 0, time.UTC)
+)
+
+func trackingNumbers(n int) []string {
+	numbers := make([]string, n)
+	for i := range numbers {
+		numbers[i] = fmt.Sprintf("9400100000000000%06d", i)
+	}
+	return numbers
+}
+
+func TestSCANFormsSplit(t *testing.T) {
+	c, requests := testClient(t, scanReply)
+
+	numbers := trackingNumbers(1500)
+	forms, err := c.SCANForms(context.Background(), scanFrom, numbers, scanDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(forms) != 2 || len(*requests) != 2 {
+		t.Fatalf("got %d forms from %d requests, want 2 of each", len(forms), len(*requests))
+	}
+	for i, want := range []int{MaxSCANPackages, 1500 - MaxSCANPackages} {
+		if got := len(forms[i].TrackingNumbers); got != want {
+			t.Errorf("form %d covers %d labels, want %d", i, got, want)
+		}
+		if got := strings.Count((*requests)[i].XML, "<PkgBarcode>"); got != want {
+			t.Errorf("request %d lists %d labels, want %d", i, got, want)
+		}
+		if forms[i].Number != "9275090104387000000000" || string(forms[i].Image) != "%PDF-" {
+			t.Errorf("form %d = %s %q", i, forms[i].Number, forms[i].Image)
+		}
+	}
+	if forms[1].TrackingNumbers[0] != numbers[MaxSCANPackages] {
+		t.Errorf("second form starts at %s, want %s", forms[1].TrackingNumbers[0], numbers[MaxSCANPackages])
+	}
+
+	for _, s := range []string{"<MailDate>20261017</MailDate>", "<MailTime>163000</MailTime>", "<FromAddress2>1 Main St</FromAddress2>", "<EntryFacility>20770</EntryFacility>"} {
+		if !strings.Contains((*requests)[0].XML, s) {
+			t.Errorf("request is missing %s", s)
+		}
+	}
+}
+
+func TestSCANFormsDuplicate(t *testing.T) {
+	c, requests := testClient(t, scanReply)
+
+	numbers := append(trackingNumbers(3), trackingNumbers(1)...)
+	if _, err := c.SCANForms(context.Background(), scanFrom, numbers, scanDate); err == nil {
+		t.Error("got no error for a duplicate tracking number")
+	}
+	if len(*requests) != 0 {
+		t.Errorf("sent %d requests, want none", len(*requests))
+	}
+}
+
+func TestSCANFormsAlreadyManifested(t *testing.T) {
+	numbers := trackingNumbers(3)
+
+	tests := []struct {
+		name, number, description string
+		want                      []string
+	}{
+		{"by number", "-2147219080", "Package " + numbers[1] + " is not eligible.", []string{numbers[1]}},
+		{"by description", "-2147200000", "Packages " + numbers[0] + ", " + numbers[2] + " have already been manifested.", []string{numbers[0], numbers[2]}},
+		{"unnamed", "-2147219081", "One or more packages are invalid.", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testClient(t, `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Number>`+tt.number+`</Number><Description>`+tt.description+`</Description></Error>`)
+
+			_, err := c.SCANForms(context.Background(), scanFrom, numbers, scanDate)
+
+			var manifested *AlreadyManifestedError
+			if !errors.As(err, &manifested) {
+				t.Fatalf("got %v, want an *AlreadyManifestedError", err)
+			}
+			if strings.Join(manifested.TrackingNumbers, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("TrackingNumbers = %v, want %v", manifested.TrackingNumbers, tt.want)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Number != tt.number {
+				t.Errorf("errors.As reached %+v, want the USPS error", apiErr)
+			}
+		})
+	}
+}
+
+func TestSCANFormsOtherError(t *testing.T) {
+	c, _ := testClient(t, scanReply, `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Number>-2147219401</Number><Description>Invalid entry facility.</Description></Error>`)
+
+	forms, err := c.SCANForms(context.Background(), scanFrom, trackingNumbers(1000), scanDate)
+
+	var manifested *AlreadyManifestedError
+	if errors.As(err, &manifested) {
+		t.Errorf("got %v, want a plain USPS error", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Errorf("got %v, want an *APIError", err)
+	}
+
+	// The first form was created before the second failed.
+	if len(forms) != 1 {
+		t.Errorf("got %d forms, want the 1 created before the error", len(forms))
+	}
+}
@@ -0,0 +1,143 @@
+package usps
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeliveryStandard is the expected transit time of a service between two ZIP
+// codes. Days counts delivery days, which are Monday to Saturday; holidays
+// are not taken into account.
+type DeliveryStandard struct {
+	Days int
+	Date time.Time
+}
+
+// The service standards API that covers each retail service.
+var standardsAPIs = map[Service]string{
+	ServicePriority:   "PriorityMail",
+	ServiceFirstClass: "FirstClassMail",
+	ServiceParcel:     "StandardB",
+	ServiceMedia:      "StandardB",
+	ServiceLibrary:    "StandardB",
+	ServiceExpress:    "ExpressMailCommitment",
+	ServiceExpressSH:  "ExpressMailCommitment",
+	ServiceExpressHFP: "ExpressMailCommitment",
+}
+
+// DeliveryStandard looks up how long service takes from zipFrom to zipTo for
+// a package accepted at the given time.
+func (c *Client) DeliveryStandard(ctx context.Context, service Service, zipFrom, zipTo string, accepted time.Time) (DeliveryStandard, error) {
+	var standard DeliveryStandard
+
+	api, ok := standardsAPIs[service.base()]
+	if !ok {
+		return standard, errors.New("usps.DeliveryStandard: No service standards for service " + string(service))
+	}
+
+	if api == "ExpressMailCommitment" {
+		return c.expressCommitment(ctx, zipFrom, zipTo, accepted)
+	}
+
+	request := &ServiceStandardRequest{
+		XMLName:        xml.Name{Local: api + "Request"},
+		UserId:         c.UserId,
+		OriginZip:      zipFrom,
+		DestinationZip: zipTo,
+	}
+
+	var response ServiceStandardResponse
+	if err := c.call(ctx, "usps.DeliveryStandard", api, request, &response); err != nil {
+		return standard, err
+	}
+
+	standard.Days = response.Days
+	standard.Date = addDeliveryDays(accepted, response.Days)
+
+	return standard, nil
+}
+
+// AddDeliveryStandards fills in DeliveryDays and DeliveryDate of every
+// estimate, looking each service up once. Estimates for services without
+// published standards are left alone.
+func (c *Client) AddDeliveryStandards(ctx context.Context, estimates []Estimate, zipFrom, zipTo string, accepted time.Time) error {
+	standards := make(map[string]DeliveryStandard)
+
+	for i := range estimates {
+		api, ok := standardsAPIs[estimates[i].Service.base()]
+		if !ok {
+			continue
+		}
+
+		standard, ok := standards[api]
+		if !ok {
+			var err error
+			standard, err = c.DeliveryStandard(ctx, estimates[i].Service, zipFrom, zipTo, accepted)
+			if err != nil {
+				return err
+			}
+			standards[api] = standard
+		}
+
+		estimates[i].DeliveryDays = standard.Days
+		estimates[i].DeliveryDate = standard.Date
+	}
+
+	return nil
+}
+
+// Priority Mail Express commitments depend on the acceptance date, so they
+// come from their own API. The fastest commitment is used.
+func (c *Client) expressCommitment(ctx context.Context, zipFrom, zipTo string, accepted time.Time) (DeliveryStandard, error) {
+	var standard DeliveryStandard
+
+	request := &ExpressMailCommitmentRequest{
+		UserId:         c.UserId,
+		OriginZIP:      zipFrom,
+		DestinationZIP: zipTo,
+		Date:           accepted.Format("02-Jan-2006"),
+	}
+
+	var response ExpressMailCommitmentResponse
+	if err := c.call(ctx, "usps.DeliveryStandard", "ExpressMailCommitment", request, &response); err != nil {
+		return standard, err
+	}
+
+	for _, commitment := range response.Commitment {
+		name, _, _ := strings.Cut(commitment.CommitmentName, "-")
+		days, err := strconv.Atoi(name)
+		if err != nil {
+			continue
+		}
+		if standard.Days == 0 || days < standard.Days {
+			standard.Days = days
+		}
+	}
+
+	if standard.Days == 0 {
+		return standard, errors.New("usps.DeliveryStandard: No Priority Mail Express commitment between " + zipFrom + " and " + zipTo)
+	}
+
+	if effective, err := time.Parse("2006-01-02", response.EffectiveAcceptanceDate); err == nil {
+		accepted = effective
+	}
+	standard.Date = addDeliveryDays(accepted, standard.Days)
+
+	return standard, nil
+}
+
+// Adds days delivery days to t, skipping Sundays. The clock part is dropped.
+func addDeliveryDays(t time.Time, days int) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for days > 0 {
+		t = t.AddDate(0, 0, 1)
+		if t.Weekday() != time.Sunday {
+			days--
+		}
+	}
+	return t
+}
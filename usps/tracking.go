@@ -0,0 +1,131 @@
+package usps
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// USPS accepts at most this many tracking numbers per TrackV2 request.
+const maxTrackBatch = 35
+
+// TrackEvent is a single scan in a package's history.
+type TrackEvent struct {
+	Code        string
+	Description string
+
+	// Time is the local time at the scan location. USPS does not send a time
+	// zone, so it is stored as UTC without conversion. It has no clock part
+	// when USPS reported only a date.
+	Time time.Time
+
+	City    string
+	State   string
+	ZIP     string
+	Country string // Empty for domestic events.
+
+	// Firm and Name identify who signed for or received the package.
+	Firm            string
+	Name            string
+	AuthorizedAgent bool
+
+	// DeliveryAttribute is the USPS delivery attribute code, e.g. "02" for a
+	// parcel locker delivery.
+	DeliveryAttribute string
+}
+
+// Tracking is the history of one tracking number.
+type Tracking struct {
+	ID string
+
+	// Summary is the most recent event and Events the ones before it, most
+	// recent first.
+	Summary TrackEvent
+	Events  []TrackEvent
+
+	// Error is set when USPS rejected this tracking number, e.g. because it is
+	// not in the system yet. The other numbers in the batch are unaffected.
+	Error *APIError
+}
+
+// Track returns the history of each tracking number, in the order given.
+// Numbers USPS reports as errors are flagged through Tracking.Error; only a
+// failure of a whole request is returned as an error.
+func (c *Client) Track(ctx context.Context, ids ...string) ([]Tracking, error) {
+	results := make([]Tracking, len(ids))
+	for i, id := range ids {
+		results[i].ID = id
+	}
+
+	for start := 0; start < len(ids); start += maxTrackBatch {
+		end := min(start+maxTrackBatch, len(ids))
+
+		request := &TrackFieldRequest{
+			UserId:   c.UserId,
+			Revision: "1",
+			ClientIp: c.ClientIP,
+			SourceId: c.SourceID,
+		}
+		for _, id := range ids[start:end] {
+			request.TrackIDs = append(request.TrackIDs, RequestTrackID{id})
+		}
+
+		var response TrackResponse
+		if err := c.call(ctx, "usps.Track", "TrackV2", request, &response); err != nil {
+			return nil, err
+		}
+
+		for _, info := range response.TrackInfo {
+			for i := start; i < end; i++ {
+				if results[i].ID != info.ID {
+					continue
+				}
+
+				if info.Error != nil {
+					info.Error.ID = info.ID
+					results[i].Error = info.Error
+					continue
+				}
+
+				if info.TrackSummary != nil {
+					results[i].Summary = info.TrackSummary.event()
+				}
+				results[i].Events = make([]TrackEvent, len(info.TrackDetail))
+				for j := range info.TrackDetail {
+					results[i].Events[j] = info.TrackDetail[j].event()
+				}
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (d *TrackDetail) event() TrackEvent {
+	return TrackEvent{
+		Code:              d.EventCode,
+		Description:       d.Event,
+		Time:              eventTime(d.EventDate, d.EventTime),
+		City:              d.EventCity,
+		State:             d.EventState,
+		ZIP:               d.EventZIPCode,
+		Country:           d.EventCountry,
+		Firm:              d.FirmName,
+		Name:              d.Name,
+		AuthorizedAgent:   strings.EqualFold(d.AuthorizedAgent, "true"),
+		DeliveryAttribute: d.DeliveryAttributeCode,
+	}
+}
+
+// Parses USPS dates like "January 6, 2016" and times like "9:24 am". The
+// zero time is returned when the date is missing or malformed.
+func eventTime(date, clock string) time.Time {
+	if clock != "" {
+		if t, err := time.Parse("January 2, 2006 3:04 pm", date+" "+clock); err == nil {
+			return t
+		}
+	}
+
+	t, _ := time.Parse("January 2, 2006", date)
+	return t
+}
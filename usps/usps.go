@@ -1,18 +1,16 @@
 package usps
 
 import (
-	"bufio"
 	"bytes"
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
-	"net/http"
-	"net/url"
-	"text/template"
-	// "strconv"
-	// "os"
+	"math"
+	"sort"
+	"strings"
+	"time"
 )
 
 /*
@@ -121,21 +119,26 @@ const (
 	ServiceOnline            Service = "ONLINE"
 )
 
-type RatingServiceSelectionResponse struct {
-	Response struct {
-		ResponseStatusCode int
+// Returns the retail service a commercial or hold-for-pickup variant belongs
+// to, which is how rate responses report it.
+func (s Service) base() Service {
+	switch s {
+	case ServiceFirstClassComm, ServiceFirstClassCommHFP:
+		return ServiceFirstClass
+	case ServicePriorityComm, ServicePriorityCommHFP:
+		return ServicePriority
+	case ServiceExpressComm:
+		return ServiceExpress
+	case ServiceExpressCommSH:
+		return ServiceExpressSH
+	case ServiceExpressCommHFP:
+		return ServiceExpressHFP
 	}
-	RatedShipment []RatedShipment
+	return s
 }
 
-type RatedShipment struct {
-	Service struct {
-		Code int
-	}
-	TotalCharges struct {
-		CurrencyCode  string
-		MonetaryValue float64
-	}
+func (s Service) commercial() bool {
+	return strings.HasSuffix(string(s), "COMMERCIAL")
 }
 
 type Shipper struct {
@@ -143,8 +146,17 @@ type Shipper struct {
 	ShipperNumber string
 }
 
+// NoRateError is returned by Rate when USPS did not price the requested
+// service, e.g. First-Class for a package that is too heavy.
+type NoRateError struct {
+	Service Service
+}
+
+func (e *NoRateError) Error() string {
+	return "usps.Rate: No rate offered for service " + string(e.Service)
+}
+
 type RateRequest struct {
-	UserId   string
 	Packages []Package
 }
 
@@ -152,6 +164,28 @@ type Estimate struct {
 	Description string // Verbal description of the estimate.
 	Service     Service
 	Cost        float64
+
+	// CommercialCost is the Commercial Base price, or zero when USPS does not
+	// offer one for this mail class.
+	CommercialCost float64
+
+	ClassID   string // USPS CLASSID of the mail class.
+	PackageID string // ID of the package in the request this estimate is for.
+
+	// Commitment is the delivery commitment, e.g. "2-Day", and CommitmentDate
+	// the expected delivery date as YYYY-MM-DD. Either may be empty.
+	Commitment     string
+	CommitmentDate string
+
+	// DimensionalWeight is the weight in pounds USPS priced the package at
+	// when dimensional weight pricing applied, and zero otherwise.
+	DimensionalWeight float64
+
+	// DeliveryDays and DeliveryDate are filled in by AddDeliveryStandards.
+	DeliveryDays int
+	DeliveryDate time.Time
+
+	SpecialServices []SpecialService
 }
 
 type Package struct {
@@ -178,12 +212,28 @@ type Package struct {
 	// LARGE: Any package dimension is larger than 12".
 	Size string
 
-	// Template Controls:
+	// Request Controls:
 	IsLarge      bool
 	IsFirstClass bool
 }
 
+// USPS limits on package size and weight.
+const (
+	maxWeight = 1120 // Ounces (70 lbs).
+
+	// Length is the longest dimension; girth is measured around the other two.
+	maxLengthPlusGirth       = 108 // Inches.
+	maxLengthPlusGirthParcel = 130 // Inches, USPS Retail Ground.
+)
+
+// Checks p against the USPS rules and fills in Size and the request controls.
+// Every problem found is reported in a ValidationErrors.
 func (p *Package) validate() error {
+	var errs ValidationErrors
+	invalid := func(field, problem string) {
+		errs = append(errs, &ValidationError{Field: field, Problem: problem})
+	}
+
 	p.Size = "REGULAR"
 	p.IsLarge = false
 	if (p.Width > 12) || (p.Height > 12) || (p.Length > 12) {
@@ -191,135 +241,167 @@ func (p *Package) validate() error {
 		p.IsLarge = true
 	}
 
-	if (p.Service == ServiceFirstClass) || (p.Service == ServiceFirstClass) || (p.Service == ServiceFirstClass) {
+	switch p.Service {
+	case ServiceFirstClass, ServiceFirstClassComm, ServiceFirstClassCommHFP:
 		p.IsFirstClass = true
+	default:
+		p.IsFirstClass = false
 	}
 
-	return nil
+	if p.ZipFrom == "" {
+		invalid("ZipFrom", "is required")
+	}
+	if p.ZipTo == "" {
+		invalid("ZipTo", "is required")
+	}
+
+	// Weights are sent to a tenth of an ounce, so that is what is checked.
+	if weight := math.Round(p.Weight*10) / 10; weight <= 0 {
+		invalid("Weight", "must be at least 0.1oz")
+	} else if weight > maxWeight {
+		invalid("Weight", "cannot be greater than 70lbs (1120oz)")
+	}
+
+	if p.IsFirstClass && p.FirstClassType == "" {
+		invalid("FirstClassType", "is required for "+string(p.Service))
+	}
+
+	if p.IsLarge {
+		if p.Container != ContainerRectangular && p.Container != ContainerNonrectangular {
+			invalid("Container", "must be RECTANGULAR or NONRECTANGULAR when Size is LARGE")
+		}
+		if p.Width <= 0 || p.Height <= 0 || p.Length <= 0 {
+			invalid("Width/Height/Length", "are all required when Size is LARGE")
+		}
+	}
+
+	limit := float64(maxLengthPlusGirth)
+	if p.Service == ServiceParcel || p.Service == ServiceAll || p.Service == ServiceOnline {
+		limit = maxLengthPlusGirthParcel
+	}
+	if lengthPlusGirth(p.Width, p.Height, p.Length) > limit {
+		invalid("Width/Height/Length", fmt.Sprintf("length plus girth cannot exceed %v\" for %s", limit, p.Service))
+	}
+
+	return errs.orNil()
 }
 
-func Rate(request *RateRequest) (Estimate, error) {
+// Length is the longest side, whichever field it is in; girth is twice the
+// sum of the other two. Validation and requests both measure this way.
+func lengthPlusGirth(width, height, length float64) float64 {
+	sides := []float64{width, height, length}
+	sort.Float64s(sides)
+	return sides[2] + 2*(sides[0]+sides[1])
+}
 
-	buf := new(bytes.Buffer)
+func girth(width, height, length float64) float64 {
+	sides := []float64{width, height, length}
+	sort.Float64s(sides)
+	return 2 * (sides[0] + sides[1])
+}
 
+// Rate prices p for the single service named in p.Service. Commercial
+// services are priced at the commercial rate. A *NoRateError is returned when
+// USPS does not offer that service for the package.
+func (c *Client) Rate(ctx context.Context, p Package) (Estimate, error) {
 	var estimate Estimate
 
-	if err := requestRate(buf, request); err != nil {
-		return estimate, errors.New("ups.Rate: Rate request failed:\n" + err.Error())
+	if p.Service == ServiceAll || p.Service == ServiceOnline || p.Service == "" {
+		return estimate, errors.New("usps.Rate: A single service is required, not " + string(p.Service))
 	}
 
-	rawxml, err := send(buf.Bytes())
+	estimates, err := c.rate(ctx, "usps.Rate", &RateRequest{Packages: []Package{p}})
 	if err != nil {
-		return estimate, errors.New("ups.Shop: Data send failed:\n" + err.Error())
+		return estimate, err
 	}
 
-	// var tree interface{}
-	var response RatingServiceSelectionResponse
-
-	err = xml.Unmarshal(rawxml, &response)
-	if err != nil {
-		return estimate, errors.New("ups.Shop: XML unmarshalling failed:\n" + err.Error())
+	want := p.Service.base()
+	found := false
+	for _, e := range estimates {
+		if e.Service != want {
+			continue
+		}
+
+		if p.Service.commercial() && e.CommercialCost > 0 {
+			e.Cost = e.CommercialCost
+		}
+
+		if !found || e.Cost < estimate.Cost {
+			estimate = e
+			found = true
+		}
 	}
 
-	var estimates []Estimate
-	for _, value := range response.RatedShipment {
-		var e Estimate
-		e.Service = Service(value.Service.Code)
-		e.Cost = value.TotalCharges.MonetaryValue
-		estimates = append(estimates, e)
+	if !found {
+		return estimate, &NoRateError{Service: p.Service}
 	}
 
+	estimate.Service = p.Service
 	return estimate, nil
 }
 
-func Shop(request *RateRequest) ([]Estimate, error) {
+func (c *Client) Shop(ctx context.Context, request *RateRequest) ([]Estimate, error) {
+	return c.rate(ctx, "usps.Shop", request)
+}
+
+// Sends a RateV4 request and flattens the postage of every package into
+// estimates. Packages that failed are reported in an Errors alongside the
+// estimates of the ones that succeeded.
+func (c *Client) rate(ctx context.Context, caller string, request *RateRequest) ([]Estimate, error) {
 	buf := new(bytes.Buffer)
 
-	if err := requestRate(buf, request); err != nil {
-		return nil, errors.New("ups.Shop: Rate request failed:\n" + err.Error())
+	if err := c.requestRate(buf, request); err != nil {
+		return nil, fmt.Errorf("%s: Rate request failed:\n%w", caller, err)
 	}
 
-	rawxml, err := send(buf.Bytes())
+	rawxml, err := c.send(ctx, "RateV4", buf.Bytes())
 	if err != nil {
-		return nil, errors.New("ups.Shop: Data send failed:\n" + err.Error())
+		return nil, fmt.Errorf("%s: Data send failed:\n%w", caller, err)
 	}
 
-	// var tree interface{}
-	var response RatingServiceSelectionResponse
+	var response RateV4Response
 
-	err = xml.Unmarshal(rawxml, &response)
+	err = decodeResponse(rawxml, &response)
 	if err != nil {
-		return nil, errors.New("ups.Shop: XML unmarshalling failed:\n" + err.Error())
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: XML unmarshalling failed:\n%w", caller, err)
 	}
 
 	var estimates []Estimate
-	for _, value := range response.RatedShipment {
-		var e Estimate
-		e.Service = Service(value.Service.Code)
-		e.Cost = value.TotalCharges.MonetaryValue
-		estimates = append(estimates, e)
+	var errs Errors
+	for _, p := range response.Packages {
+		if p.Error != nil {
+			p.Error.ID = p.ID
+			errs = append(errs, p.Error)
+			continue
+		}
+
+		for _, postage := range p.Postage {
+			estimates = append(estimates, postage.estimate(&p))
+		}
 	}
 
-	return estimates, nil
+	return estimates, errs.orNil()
 }
 
-// This does the actual processing of the UPS Rate Request. Rate() and Shop() are both front-ends to this function.
-func requestRate(w io.Writer, req *RateRequest) error {
-	// Parse and execute the templates.
-	tdir := "./templates/usps/"
-
-	templates := []string{
-		tdir + "raterequest.xml"}
-	page, err := template.ParseFiles(templates...)
-	if err != nil {
-		return errors.New("USPS Test: Unable to parse templates:\n" + err.Error())
+// This does the actual processing of the USPS Rate Request. Rate() and Shop() are both front-ends to this function.
+func (c *Client) requestRate(w io.Writer, req *RateRequest) error {
+	for i := range req.Packages {
+		if err := req.Packages[i].validate(); err != nil {
+			return err
+		}
 	}
 
-	err = page.ExecuteTemplate(w, "raterequest.xml", req)
-	if err != nil {
-		return errors.New("USPS Test: Rate request template execution failed:\n" + err.Error())
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
 	}
 
-	return nil
-}
-
-func send(data []byte) ([]byte, error) {
-	fmt.Printf("\n\n%s\n\n", data)
-
-	//	target := "https://secure.shippingapis.com/ShippingAPITest.dll"
-	target := "http://testing.shippingapis.com//ShippingAPITest.dll"
-
-	ioutil.WriteFile("request.xml", data, 0644)
-
-	client := new(http.Client)
-
-	values := url.Values{}
-	values.Add("API", "RateV2")
-	values.Add("XML", fmt.Sprintf("%s", data))
-
-	response, err := client.PostForm(target, values)
-	if err != nil {
-		fmt.Println("ups.send: Error while sending XML request:\n", err.Error())
-	}
-
-	bufout := new(bytes.Buffer)
-	r := bufio.NewReader(response.Body)
-	defer response.Body.Close()
-
-	line, _, err := r.ReadLine()
-	for err == nil {
-		bufout.Write(line)
-		line, _, err = r.ReadLine()
+	if err := xml.NewEncoder(w).Encode(newRateV4Request(c.UserId, req)); err != nil {
+		return fmt.Errorf("usps.requestRate: XML marshalling failed:\n%w", err)
 	}
-	if err != io.EOF {
-		fmt.Println("ups.send: Error while reading response:\n", err.Error())
-	}
-
-	rawxml := bufout.Bytes()
-	ioutil.WriteFile("response.xml", rawxml, 0644)
-
-	fmt.Printf("\n\n%s\n\n", rawxml)
-
-	return rawxml, nil
 
+	return nil
 }
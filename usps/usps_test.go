@@ -0,0 +1,219 @@
+package usps
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRate(t *testing.T) {
+	tests := []struct {
+		service Service
+		cost    float64
+		classID string
+	}{
+		// The cheapest Priority Mail option is the flat rate box at retail,
+		// and the plain 2-Day rate at commercial prices.
+		{ServicePriority, 22.80, "22"},
+		{ServicePriorityComm, 21.07, "1"},
+		{ServiceExpress, 74.35, "3"},
+		{ServiceExpressComm, 64.85, "3"},
+		{ServiceExpressHFP, 74.35, "2"},
+		{ServiceExpressCommHFP, 64.85, "2"},
+		// No commercial rate is offered, so the retail one is used.
+		{ServiceExpressCommSH, 86.85, "23"},
+		{ServiceParcel, 26.35, "4"},
+		{ServiceMedia, 8.18, "6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.service), func(t *testing.T) {
+			c, requests := fixtureClient(t, "rate_v4_response.xml")
+
+			e, err := c.Rate(context.Background(), Package{
+				Service: tt.service, Container: ContainerVariable, ZipFrom: "20770", ZipTo: "54901", Weight: 100,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if e.Cost != tt.cost || e.ClassID != tt.classID {
+				t.Errorf("got %v (class %s), want %v (class %s)", e.Cost, e.ClassID, tt.cost, tt.classID)
+			}
+			if e.Service != tt.service {
+				t.Errorf("Service = %s, want %s", e.Service, tt.service)
+			}
+			if sent := (*requests)[0].XML; !strings.Contains(sent, "<Service>"+string(tt.service)+"</Service>") {
+				t.Errorf("request does not ask for %s:\n%s", tt.service, sent)
+			}
+		})
+	}
+}
+
+func TestRateNoRate(t *testing.T) {
+	c, _ := fixtureClient(t, "rate_v4_response.xml")
+
+	_, err := c.Rate(context.Background(), Package{
+		Service: ServiceFirstClass, FirstClassType: FirstClassParcel, Container: ContainerVariable, ZipFrom: "20770", ZipTo: "54901", Weight: 100,
+	})
+
+	var noRate *NoRateError
+	if !errors.As(err, &noRate) {
+		t.Fatalf("got %v, want a *NoRateError", err)
+	}
+	if noRate.Service != ServiceFirstClass {
+		t.Errorf("Service = %s, want %s", noRate.Service, ServiceFirstClass)
+	}
+}
+
+func TestRateSingleService(t *testing.T) {
+	c, requests := fixtureClient(t, "rate_v4_response.xml")
+
+	for _, service := range []Service{"", ServiceAll, ServiceOnline} {
+		_, err := c.Rate(context.Background(), Package{Service: service, ZipFrom: "20770", ZipTo: "54901", Weight: 10})
+		if err == nil {
+			t.Errorf("Rate with service %q: got no error", service)
+		}
+	}
+	if len(*requests) != 0 {
+		t.Errorf("sent %d requests, want none", len(*requests))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := Package{Service: ServicePriority, Container: ContainerVariable, ZipFrom: "20770", ZipTo: "54901", Weight: 10}
+
+	tests := []struct {
+		name   string
+		change func(p *Package)
+		fields []string
+	}{
+		{"valid", func(p *Package) {}, nil},
+		{"max weight", func(p *Package) { p.Weight = 1120 }, nil},
+		{"rounds down to max weight", func(p *Package) { p.Weight = 1120.04 }, nil},
+		{"over weight", func(p *Package) { p.Weight = 1120.1 }, []string{"Weight"}},
+		{"no weight", func(p *Package) { p.Weight = 0 }, []string{"Weight"}},
+		{"rounds to no weight", func(p *Package) { p.Weight = 0.04 }, []string{"Weight"}},
+		{"smallest weight", func(p *Package) { p.Weight = 0.05 }, nil},
+		{"no zips", func(p *Package) { p.ZipFrom, p.ZipTo = "", "" }, []string{"ZipFrom", "ZipTo"}},
+		{"large variable", func(p *Package) { p.Width, p.Height, p.Length = 14, 10, 10 }, []string{"Container"}},
+		{"large rectangular", func(p *Package) {
+			p.Container = ContainerRectangular
+			p.Width, p.Height, p.Length = 14, 10, 10
+		}, nil},
+		{"large missing side", func(p *Package) {
+			p.Container = ContainerNonrectangular
+			p.Width, p.Length = 14, 10
+		}, []string{"Width/Height/Length"}},
+		{"first class without type", func(p *Package) { p.Service = ServiceFirstClass }, []string{"FirstClassType"}},
+		{"commercial first class without type", func(p *Package) { p.Service = ServiceFirstClassComm }, []string{"FirstClassType"}},
+		{"first class with type", func(p *Package) {
+			p.Service = ServiceFirstClass
+			p.FirstClassType = FirstClassParcel
+		}, nil},
+		// 30 + 2*(20+19) = 108, the Priority Mail limit.
+		{"length plus girth at 108", func(p *Package) {
+			p.Container = ContainerRectangular
+			p.Width, p.Height, p.Length = 20, 19, 30
+		}, nil},
+		{"length plus girth over 108", func(p *Package) {
+			p.Container = ContainerRectangular
+			p.Width, p.Height, p.Length = 20, 19.5, 30
+		}, []string{"Width/Height/Length"}},
+		// The longest side counts as the length whichever field it is in.
+		{"length plus girth over 108 rotated", func(p *Package) {
+			p.Container = ContainerRectangular
+			p.Width, p.Height, p.Length = 30, 20, 19.5
+		}, []string{"Width/Height/Length"}},
+		{"length plus girth over 108 for ground", func(p *Package) {
+			p.Service = ServiceParcel
+			p.Container = ContainerRectangular
+			p.Width, p.Height, p.Length = 20, 19.5, 30
+		}, nil},
+		// 40 + 2*(22+23) = 130, the USPS Retail Ground limit.
+		{"length plus girth at 130", func(p *Package) {
+			p.Service = ServiceParcel
+			p.Container = ContainerRectangular
+			p.Width, p.Height, p.Length = 22, 23, 40
+		}, nil},
+		{"length plus girth over 130", func(p *Package) {
+			p.Service = ServiceAll
+			p.Container = ContainerRectangular
+			p.Width, p.Height, p.Length = 22, 23.5, 40
+		}, []string{"Width/Height/Length"}},
+		{"everything wrong", func(p *Package) {
+			p.Service = ServiceFirstClass
+			p.ZipTo = ""
+			p.Weight = 2000
+			p.Width, p.Height, p.Length = 30, 30, 30
+		}, []string{"ZipTo", "Weight", "FirstClassType", "Container", "Width/Height/Length"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid
+			tt.change(&p)
+
+			err := p.validate()
+			if tt.fields == nil {
+				if err != nil {
+					t.Errorf("got %v, want no error", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("got %v, want ValidationErrors", err)
+			}
+			fields := make([]string, len(errs))
+			for i, e := range errs {
+				fields[i] = e.Field
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.fields, ",") {
+				t.Errorf("problems with %v, want %v: %v", fields, tt.fields, err)
+			}
+
+			var single *ValidationError
+			if !errors.As(err, &single) || single != errs[0] {
+				t.Errorf("errors.As did not reach the first *ValidationError")
+			}
+		})
+	}
+}
+
+func TestValidateSetsControls(t *testing.T) {
+	p := Package{Service: ServiceFirstClassCommHFP, FirstClassType: FirstClassParcel, Container: ContainerRectangular, ZipFrom: "20770", ZipTo: "54901", Weight: 10, Width: 13, Height: 2, Length: 2}
+	if err := p.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if p.Size != "LARGE" || !p.IsLarge || !p.IsFirstClass {
+		t.Errorf("got Size %s, IsLarge %v, IsFirstClass %v; want LARGE, true, true", p.Size, p.IsLarge, p.IsFirstClass)
+	}
+}
+
+// Validation errors reach the caller of Rate and Label unchanged.
+func TestValidationErrorsReachCallers(t *testing.T) {
+	c, requests := testClient(t, "")
+
+	_, err := c.Rate(context.Background(), Package{Service: ServicePriority, Weight: 0.04})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("Rate: got %v, want ValidationErrors", err)
+	}
+
+	_, err = c.Label(context.Background(), &LabelRequest{Package: Package{Service: ServicePriority}})
+	if !errors.As(err, &errs) {
+		t.Errorf("Label: got %v, want ValidationErrors", err)
+	}
+
+	_, err = c.ReturnLabel(context.Background(), &ReturnLabelRequest{Package: Package{Service: ServicePriority}})
+	if !errors.As(err, &errs) {
+		t.Errorf("ReturnLabel: got %v, want ValidationErrors", err)
+	}
+
+	if len(*requests) != 0 {
+		t.Errorf("sent %d requests, want none", len(*requests))
+	}
+}
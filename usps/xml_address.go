@@ -0,0 +1,87 @@
+package usps
+
+import (
+	"encoding/xml"
+)
+
+// Note that USPS puts the secondary unit (apartment, suite) in <Address1> and
+// the street in <Address2>. The Go-facing Address type swaps them back.
+type RequestAddress struct {
+	ID           string `xml:",attr"`
+	FirmName     string
+	Address1     string
+	Address2     string
+	City         string
+	State        string
+	Urbanization string `xml:",omitempty"`
+	Zip5         string
+	Zip4         string
+}
+
+type ResponseAddress struct {
+	ID                   string `xml:",attr"`
+	FirmName             string
+	Address1             string
+	Address2             string
+	City                 string
+	State                string
+	Urbanization         string
+	Zip5                 string
+	Zip4                 string
+	DeliveryPoint        string
+	CarrierRoute         string
+	ReturnText           string
+	Footnotes            string
+	DPVConfirmation      string
+	DPVCMRA              string
+	DPVFootnotes         string
+	Business             string
+	CentralDeliveryPoint string
+	Vacant               string
+	Error                *APIError
+}
+
+type AddressValidateRequest struct {
+	XMLName   xml.Name `xml:"AddressValidateRequest"`
+	UserId    string   `xml:"USERID,attr"`
+	Revision  string
+	Addresses []RequestAddress `xml:"Address"`
+}
+
+type AddressValidateResponse struct {
+	XMLName   xml.Name          `xml:"AddressValidateResponse"`
+	Addresses []ResponseAddress `xml:"Address"`
+}
+
+type ZipCodeLookupRequest struct {
+	XMLName   xml.Name         `xml:"ZipCodeLookupRequest"`
+	UserId    string           `xml:"USERID,attr"`
+	Addresses []RequestAddress `xml:"Address"`
+}
+
+type ZipCodeLookupResponse struct {
+	XMLName   xml.Name          `xml:"ZipCodeLookupResponse"`
+	Addresses []ResponseAddress `xml:"Address"`
+}
+
+type RequestZipCode struct {
+	ID   string `xml:",attr"`
+	Zip5 string
+}
+
+type CityStateLookupRequest struct {
+	XMLName  xml.Name         `xml:"CityStateLookupRequest"`
+	UserId   string           `xml:"USERID,attr"`
+	ZipCodes []RequestZipCode `xml:"ZipCode"`
+}
+
+type CityStateLookupResponse struct {
+	XMLName  xml.Name `xml:"CityStateLookupResponse"`
+	ZipCodes []struct {
+		ID    string `xml:",attr"`
+		Zip5  string
+		City  string
+		State string
+		Error *APIError
+	} `xml:"ZipCode"`
+}
@@ -0,0 +1,65 @@
+package usps
+
+import (
+	"encoding/xml"
+)
+
+const intlRateV2Revision = "2"
+
+type IntlRateV2Request struct {
+	XMLName  xml.Name `xml:"IntlRateV2Request"`
+	UserId   string   `xml:"USERID,attr"`
+	Revision string
+	Packages []IntlRateV2RequestPackage `xml:"Package"`
+}
+
+// Element order matters to USPS; keep it in sync with the IntlRateV2 schema.
+type IntlRateV2RequestPackage struct {
+	ID              string `xml:",attr"`
+	Pounds          int
+	Ounces          float64
+	Machinable      bool
+	MailType        MailType
+	ValueOfContents string
+	Country         string
+	Container       Container `xml:",omitempty"`
+	Size            string
+	Width           float64 `xml:",omitempty"`
+	Length          float64 `xml:",omitempty"`
+	Height          float64 `xml:",omitempty"`
+	Girth           float64 `xml:",omitempty"`
+	OriginZip       string  `xml:",omitempty"`
+	CommercialFlag  string  `xml:",omitempty"`
+}
+
+type IntlRateV2Response struct {
+	XMLName  xml.Name                    `xml:"IntlRateV2Response"`
+	Packages []IntlRateV2ResponsePackage `xml:"Package"`
+}
+
+type IntlRateV2ResponsePackage struct {
+	ID                     string `xml:",attr"`
+	Prohibitions           string
+	Restrictions           string
+	Observations           string
+	CustomsForms           string
+	ExpressMail            string
+	AreasServed            string
+	AdditionalRestrictions string
+	Service                []IntlService
+	Error                  *APIError
+}
+
+type IntlService struct {
+	ID                string `xml:",attr"`
+	Pounds            int
+	Ounces            float64
+	MailType          MailType
+	Country           string
+	Postage           float64
+	CommercialPostage float64
+	SvcCommitments    string
+	SvcDescription    string // HTML-escaped, with trademark markup.
+	MaxDimensions     string
+	MaxWeight         float64
+}
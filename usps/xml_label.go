@@ -0,0 +1,84 @@
+package usps
+
+import (
+	"encoding/xml"
+)
+
+// As with the Address APIs, <FromAddress1>/<ToAddress1> hold the secondary
+// unit and <FromAddress2>/<ToAddress2> the street.
+type EVSRequest struct {
+	XMLName         xml.Name `xml:"eVSRequest"`
+	UserId          string   `xml:"USERID,attr"`
+	Option          string
+	Revision        string
+	ImageParameters struct {
+		ImageParameter string
+	}
+	FromName                   string
+	FromFirm                   string
+	FromAddress1               string
+	FromAddress2               string
+	FromCity                   string
+	FromState                  string
+	FromZip5                   string
+	FromZip4                   string
+	FromPhone                  string
+	AllowNonCleansedOriginAddr bool
+	ToName                     string
+	ToFirm                     string
+	ToAddress1                 string
+	ToAddress2                 string
+	ToCity                     string
+	ToState                    string
+	ToZip5                     string
+	ToZip4                     string
+	ToPhone                    string
+	AllowNonCleansedDestAddr   bool
+	WeightInOunces             float64
+	ServiceType                string
+	Container                  Container
+	Width                      float64 `xml:",omitempty"`
+	Length                     float64 `xml:",omitempty"`
+	Height                     float64 `xml:",omitempty"`
+	Girth                      float64 `xml:",omitempty"`
+	Machinable                 bool
+	CustomerRefNo              string
+	CustomerRefNo2             string `xml:",omitempty"`
+	ImageType                  LabelImageType
+	HoldForManifest            string
+}
+
+type EVSResponse struct {
+	XMLName       xml.Name `xml:"eVSResponse"`
+	BarcodeNumber string
+	LabelImage    string // Base64.
+	ToName        string
+	ToFirm        string
+	ToAddress1    string
+	ToAddress2    string
+	ToCity        string
+	ToState       string
+	ToZip5        string
+	ToZip4        string
+	Postage       float64
+	RDC           string
+	CarrierRoute  string
+	Zone          string
+	Commitment    struct {
+		CommitmentName        string
+		ScheduledDeliveryDate string
+	}
+}
+
+type EVSCancelRequest struct {
+	XMLName       xml.Name `xml:"eVSCancelRequest"`
+	UserId        string   `xml:"USERID,attr"`
+	BarcodeNumber string
+}
+
+type EVSCancelResponse struct {
+	XMLName       xml.Name `xml:"eVSCancelResponse"`
+	BarcodeNumber string
+	Status        string
+	Reason        string
+}
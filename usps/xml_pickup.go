@@ -0,0 +1,87 @@
+package usps
+
+import (
+	"encoding/xml"
+)
+
+// The address block shared by all Carrier Pickup requests and responses.
+type PickupAddress struct {
+	FirmName     string
+	SuiteOrApt   string
+	Address2     string
+	Urbanization string
+	City         string
+	State        string
+	ZIP5         string
+	ZIP4         string
+}
+
+type PickupPackageType struct {
+	ServiceType PickupService
+	Count       int
+}
+
+type CarrierPickupAvailabilityRequest struct {
+	XMLName xml.Name `xml:"CarrierPickupAvailabilityRequest"`
+	UserId  string   `xml:"USERID,attr"`
+	PickupAddress
+}
+
+type CarrierPickupAvailabilityResponse struct {
+	XMLName xml.Name `xml:"CarrierPickupAvailabilityResponse"`
+	PickupAddress
+	DayOfWeek    string
+	Date         string
+	CarrierRoute string
+}
+
+// Element order matters to USPS. Schedule and Change share this layout;
+// ConfirmationNumber is only sent with Change.
+type CarrierPickupRequest struct {
+	XMLName   xml.Name
+	UserId    string `xml:"USERID,attr"`
+	FirstName string
+	LastName  string
+	PickupAddress
+	Phone               string
+	Extension           string
+	Packages            []PickupPackageType `xml:"Package"`
+	EstimatedWeight     int
+	PackageLocation     PickupLocation
+	SpecialInstructions string
+	ConfirmationNumber  string `xml:",omitempty"`
+	EmailAddress        string `xml:",omitempty"`
+}
+
+// Used by Cancel and Inquiry.
+type CarrierPickupLookupRequest struct {
+	XMLName xml.Name
+	UserId  string `xml:"USERID,attr"`
+	PickupAddress
+	ConfirmationNumber string
+}
+
+// The response to Schedule, Change and Inquiry.
+type CarrierPickupResponse struct {
+	FirstName string
+	LastName  string
+	PickupAddress
+	Phone               string
+	Extension           string
+	Packages            []PickupPackageType `xml:"Package"`
+	EstimatedWeight     int
+	PackageLocation     PickupLocation
+	SpecialInstructions string
+	ConfirmationNumber  string
+	DayOfWeek           string
+	Date                string
+	CarrierRoute        string
+	Status              string
+}
+
+type CarrierPickupCancelResponse struct {
+	XMLName xml.Name `xml:"CarrierPickupCancelResponse"`
+	PickupAddress
+	ConfirmationNumber string
+	Status             string
+}
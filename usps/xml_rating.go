@@ -0,0 +1,197 @@
+package usps
+
+import (
+	"encoding/xml"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Revision 2 of RateV4 adds commitment dates and dimensional weight data to
+// the response.
+const rateV4Revision = "2"
+
+type RateV4Request struct {
+	XMLName  xml.Name `xml:"RateV4Request"`
+	UserId   string   `xml:"USERID,attr"`
+	Revision string
+	Packages []RateV4RequestPackage `xml:"Package"`
+}
+
+// Element order matters to USPS; keep it in sync with the RateV4 schema.
+type RateV4RequestPackage struct {
+	ID                 string `xml:",attr"`
+	Service            Service
+	FirstClassMailType FirstClassType `xml:",omitempty"`
+	ZipOrigination     string
+	ZipDestination     string
+	Pounds             int
+	Ounces             float64
+	Container          Container
+	Size               string
+
+	// Dimensions are only sent for LARGE packages.
+	Width  float64 `xml:",omitempty"`
+	Length float64 `xml:",omitempty"`
+	Height float64 `xml:",omitempty"`
+	Girth  float64 `xml:",omitempty"`
+}
+
+// Builds the RateV4 document for req. Packages must already be validated.
+func newRateV4Request(userId string, req *RateRequest) *RateV4Request {
+	r := &RateV4Request{
+		UserId:   userId,
+		Revision: rateV4Revision,
+		Packages: make([]RateV4RequestPackage, len(req.Packages)),
+	}
+
+	for i, p := range req.Packages {
+		pounds, ounces := splitWeight(p.Weight)
+
+		rp := RateV4RequestPackage{
+			ID:             strconv.Itoa(i),
+			Service:        p.Service,
+			ZipOrigination: p.ZipFrom,
+			ZipDestination: p.ZipTo,
+			Pounds:         pounds,
+			Ounces:         ounces,
+			Container:      p.Container,
+			Size:           p.Size,
+		}
+
+		if p.IsFirstClass {
+			rp.FirstClassMailType = p.FirstClassType
+		}
+
+		if p.IsLarge {
+			rp.Width = p.Width
+			rp.Length = p.Length
+			rp.Height = p.Height
+			if p.Container == ContainerNonrectangular {
+				rp.Girth = girth(p.Width, p.Height, p.Length)
+			}
+		}
+
+		r.Packages[i] = rp
+	}
+
+	return r
+}
+
+// Splits a weight in ounces into whole pounds and the remaining ounces, the
+// way USPS requests expect it. The weight is rounded to one decimal place
+// first, so 15.96oz is sent as 1lb 0oz rather than 0lb 16oz.
+func splitWeight(weight float64) (int, float64) {
+	weight = math.Round(weight*10) / 10
+	pounds := int(weight / 16)
+	ounces := math.Round((weight-float64(pounds)*16)*10) / 10
+	return pounds, ounces
+}
+
+type RateV4Response struct {
+	XMLName  xml.Name                `xml:"RateV4Response"`
+	Packages []RateV4ResponsePackage `xml:"Package"`
+}
+
+type RateV4ResponsePackage struct {
+	ID                 string `xml:",attr"`
+	ZipOrigination     string
+	ZipDestination     string
+	Pounds             int
+	Ounces             float64
+	FirstClassMailType FirstClassType
+	Container          Container
+	Size               string
+	Machinable         string
+	Zone               string
+
+	// Weight in pounds USPS priced the package at when dimensional weight
+	// pricing applied; zero otherwise.
+	DimensionalWeight float64
+
+	Postage []Postage
+	Error   *APIError
+}
+
+type Postage struct {
+	ClassID            string `xml:"CLASSID,attr"`
+	MailService        string // HTML-escaped, with trademark markup.
+	Rate               float64
+	CommercialRate     float64
+	CommercialPlusRate float64
+	CommitmentDate     string
+	CommitmentName     string
+	SpecialServices    struct {
+		SpecialService []SpecialService
+	}
+}
+
+type SpecialService struct {
+	ServiceID             string
+	ServiceName           string
+	Available             bool
+	AvailableOnline       bool
+	Price                 float64
+	PriceOnline           float64
+	DeclaredValueRequired bool
+	DueSenderRequired     bool
+}
+
+func (p *Postage) estimate(pkg *RateV4ResponsePackage) Estimate {
+	description := readableMailService(p.MailService)
+
+	return Estimate{
+		Description:       description,
+		Service:           serviceForMailService(description),
+		Cost:              p.Rate,
+		CommercialCost:    p.CommercialRate,
+		ClassID:           p.ClassID,
+		PackageID:         pkg.ID,
+		Commitment:        p.CommitmentName,
+		CommitmentDate:    p.CommitmentDate,
+		DimensionalWeight: pkg.DimensionalWeight,
+		SpecialServices:   p.SpecialServices.SpecialService,
+	}
+}
+
+var markup = regexp.MustCompile(`<sup>.*?</sup>|<[^>]*>`)
+
+// USPS sends mail service names as escaped HTML, e.g.
+// "Priority Mail 2-Day&lt;sup&gt;&#8482;&lt;/sup&gt;". This strips the
+// markup and trademark symbols, leaving "Priority Mail 2-Day".
+func readableMailService(s string) string {
+	s = html.UnescapeString(s)
+	s = markup.ReplaceAllString(s, "")
+	s = strings.NewReplacer("™", "", "®", "").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Maps a readable mail service name back to the Service it belongs to.
+// Returns an empty Service for mail classes this package does not model.
+func serviceForMailService(name string) Service {
+	switch {
+	case strings.HasPrefix(name, "Priority Mail Express"):
+		switch {
+		case strings.Contains(name, "Hold For Pickup"):
+			return ServiceExpressHFP
+		case strings.Contains(name, "Sunday/Holiday"):
+			return ServiceExpressSH
+		}
+		return ServiceExpress
+	case strings.HasPrefix(name, "Priority Mail"):
+		return ServicePriority
+	case strings.HasPrefix(name, "First-Class"):
+		return ServiceFirstClass
+	case strings.HasPrefix(name, "USPS Retail Ground"),
+		strings.HasPrefix(name, "Standard Post"),
+		strings.HasPrefix(name, "Parcel Post"):
+		return ServiceParcel
+	case strings.HasPrefix(name, "Media Mail"):
+		return ServiceMedia
+	case strings.HasPrefix(name, "Library Mail"):
+		return ServiceLibrary
+	}
+	return ""
+}
@@ -0,0 +1,316 @@
+package usps
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// Compares got with testdata/name, or rewrites the file when -update is set.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s mismatch:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+var (
+	allContainers = []Container{
+		ContainerVariable,
+		ContainerFlatRateEnv,
+		ContainerFlatRateEnvPadded,
+		ContainerFlatRateEnvLegal,
+		ContainerFlatRateEnvSmall,
+		ContainerFlatRateEnvWindow,
+		ContainerFlatRateEnvGiftCard,
+		ContainerBoxFlatRate,
+		ContainerBoxFlatRateSmall,
+		ContainerBoxFlatRateMedium,
+		ContainerBoxFlatRateLarge,
+		ContainerRegionalRateBoxA,
+		ContainerRegionalRateBoxB,
+		ContainerRegionalRateBoxC,
+		ContainerRectangular,
+		ContainerNonrectangular,
+	}
+
+	allFirstClassTypes = []FirstClassType{
+		FirstClassLetter,
+		FirstClassFlat,
+		FirstClassParcel,
+		FirstClassPostcard,
+		FirstClassPackageService,
+	}
+
+	allServices = []Service{
+		ServiceFirstClass,
+		ServiceFirstClassComm,
+		ServiceFirstClassCommHFP,
+		ServicePriority,
+		ServicePriorityComm,
+		ServicePriorityCommHFP,
+		ServiceExpress,
+		ServiceExpressComm,
+		ServiceExpressSH,
+		ServiceExpressCommSH,
+		ServiceExpressHFP,
+		ServiceExpressCommHFP,
+		ServiceParcel,
+		ServiceMedia,
+		ServiceLibrary,
+		ServiceAll,
+		ServiceOnline,
+	}
+)
+
+func TestRequestRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		packages []Package
+	}{
+		{"regular", []Package{
+			{Service: ServicePriority, Container: ContainerVariable, ZipFrom: "20770", ZipTo: "54901", Weight: 40, Width: 10, Height: 6, Length: 8},
+		}},
+		{"first_class", []Package{
+			{Service: ServiceFirstClass, FirstClassType: FirstClassParcel, Container: ContainerVariable, ZipFrom: "20770", ZipTo: "54901", Weight: 3.5},
+		}},
+		{"large_rectangular", []Package{
+			{Service: ServicePriority, Container: ContainerRectangular, ZipFrom: "20770", ZipTo: "54901", Weight: 120, Width: 14, Height: 10, Length: 20},
+		}},
+		// Length is in the Width field; girth must still be measured around
+		// the two shortest sides.
+		{"large_nonrectangular", []Package{
+			{Service: ServicePriority, Container: ContainerNonrectangular, ZipFrom: "20770", ZipTo: "54901", Weight: 120, Width: 30, Height: 6, Length: 8},
+		}},
+		{"weight_carry", []Package{
+			{Service: ServicePriority, Container: ContainerVariable, ZipFrom: "20770", ZipTo: "54901", Weight: 15.96},
+		}},
+		{"max_weight", []Package{
+			{Service: ServiceParcel, Container: ContainerVariable, ZipFrom: "20770", ZipTo: "54901", Weight: 1120},
+		}},
+		{"shop", []Package{
+			{Service: ServiceAll, Container: ContainerVariable, ZipFrom: "20770", ZipTo: "54901", Weight: 8},
+			{Service: ServiceFirstClassComm, FirstClassType: FirstClassPackageService, Container: ContainerVariable, ZipFrom: "20770", ZipTo: "54901", Weight: 8},
+		}},
+	}
+
+	c := &Client{UserId: "TESTUSER"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := c.requestRate(buf, &RateRequest{Packages: tt.packages}); err != nil {
+				t.Fatal(err)
+			}
+			golden(t, "rate_v4_"+tt.name+".xml", buf.Bytes())
+		})
+	}
+}
+
+// Renders the whole RateV4 document for one package of every Service and
+// Container, plus LARGE RECTANGULAR and NONRECTANGULAR packages. FirstClassType
+// is only sent for First-Class services, so only those are repeated for each
+// type.
+func TestRequestRateCombinations(t *testing.T) {
+	c := &Client{UserId: "TESTUSER"}
+	buf := new(bytes.Buffer)
+
+	add := func(p Package) {
+		fmt.Fprintf(buf, "# %s|%s|%s\n", p.Service, p.Container, p.FirstClassType)
+		if err := c.requestRate(buf, &RateRequest{Packages: []Package{p}}); err != nil {
+			t.Fatalf("%s/%s/%s: %v", p.Service, p.Container, p.FirstClassType, err)
+		}
+		buf.WriteString("\n")
+	}
+
+	for _, service := range allServices {
+		firstClassTypes := []FirstClassType{""}
+		switch service {
+		case ServiceFirstClass, ServiceFirstClassComm, ServiceFirstClassCommHFP:
+			firstClassTypes = allFirstClassTypes
+		}
+
+		for _, firstClassType := range firstClassTypes {
+			for _, container := range allContainers {
+				add(Package{
+					Service:        service,
+					FirstClassType: firstClassType,
+					Container:      container,
+					ZipFrom:        "20770",
+					ZipTo:          "54901",
+					Weight:         10,
+					Width:          6,
+					Height:         4,
+					Length:         10,
+				})
+			}
+
+			for _, container := range []Container{ContainerRectangular, ContainerNonrectangular} {
+				add(Package{
+					Service:        service,
+					FirstClassType: firstClassType,
+					Container:      container,
+					ZipFrom:        "20770",
+					ZipTo:          "54901",
+					Weight:         100,
+					Width:          15,
+					Height:         5,
+					Length:         10,
+				})
+			}
+		}
+	}
+
+	golden(t, "rate_v4_combinations.txt", buf.Bytes())
+}
+
+func TestSplitWeight(t *testing.T) {
+	tests := []struct {
+		weight float64
+		pounds int
+		ounces float64
+	}{
+		{0.1, 0, 0.1},
+		{8, 0, 8},
+		{15.94, 0, 15.9},
+		{15.95, 1, 0},
+		{15.96, 1, 0},
+		{16, 1, 0},
+		{16.04, 1, 0},
+		{16.05, 1, 0.1},
+		{31.96, 2, 0},
+		{40.25, 2, 8.3},
+		{1120, 70, 0},
+	}
+
+	for _, tt := range tests {
+		pounds, ounces := splitWeight(tt.weight)
+		if pounds != tt.pounds || ounces != tt.ounces {
+			t.Errorf("splitWeight(%v) = %d, %v; want %d, %v", tt.weight, pounds, ounces, tt.pounds, tt.ounces)
+		}
+	}
+}
+
+// Returns a Client that answers every request with testdata/name.
+func fixtureClient(t *testing.T, name string) (*Client, *[]sent) {
+	t.Helper()
+
+	reply, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testClient(t, string(reply))
+}
+
+func TestRateV4ResponseMapping(t *testing.T) {
+	c, _ := fixtureClient(t, "rate_v4_response.xml")
+
+	estimates, err := c.Shop(context.Background(), &RateRequest{Packages: []Package{
+		{Service: ServiceAll, Container: ContainerRectangular, ZipFrom: "20770", ZipTo: "54901", Weight: 100, Width: 15, Height: 10, Length: 12},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type mapped struct {
+		Description       string
+		Service           Service
+		Cost              float64
+		CommercialCost    float64
+		ClassID           string
+		Commitment        string
+		CommitmentDate    string
+		DimensionalWeight float64
+	}
+	want := []mapped{
+		{"Priority Mail Express 1-Day", ServiceExpress, 74.35, 64.85, "3", "1-Day", "2026-10-19", 9},
+		{"Priority Mail Express 1-Day Hold For Pickup", ServiceExpressHFP, 74.35, 64.85, "2", "1-Day", "2026-10-19", 9},
+		{"Priority Mail Express 1-Day Sunday/Holiday Delivery", ServiceExpressSH, 86.85, 0, "23", "1-Day", "2026-10-18", 9},
+		{"Priority Mail 2-Day", ServicePriority, 27.40, 21.07, "1", "2-Day", "2026-10-20", 9},
+		{"Priority Mail 2-Day Large Flat Rate Box", ServicePriority, 22.80, 21.90, "22", "2-Day", "2026-10-20", 9},
+		{"USPS Retail Ground", ServiceParcel, 26.35, 0, "4", "", "", 9},
+		{"Media Mail Parcel", ServiceMedia, 8.18, 0, "6", "", "", 9},
+		{"Library Mail Parcel", ServiceLibrary, 7.78, 0, "7", "", "", 9},
+	}
+
+	if len(estimates) != len(want) {
+		t.Fatalf("got %d estimates, want %d", len(estimates), len(want))
+	}
+	for i, e := range estimates {
+		got := mapped{e.Description, e.Service, e.Cost, e.CommercialCost, e.ClassID, e.Commitment, e.CommitmentDate, e.DimensionalWeight}
+		if got != want[i] {
+			t.Errorf("estimate %d = %+v\nwant %+v", i, got, want[i])
+		}
+		if e.PackageID != "0" {
+			t.Errorf("estimate %d is for package %q, want 0", i, e.PackageID)
+		}
+	}
+
+	special := estimates[3].SpecialServices
+	if len(special) != 1 || special[0].ServiceName != "Insurance" || special[0].Price != 2.75 || !special[0].DeclaredValueRequired {
+		t.Errorf("got special services %+v, want Insurance at 2.75", special)
+	}
+}
+
+func TestReadableMailService(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Priority Mail 2-Day&lt;sup&gt;&#8482;&lt;/sup&gt;", "Priority Mail 2-Day"},
+		{"Priority Mail Express 1-Day&lt;sup&gt;&#8482;&lt;/sup&gt; Hold For Pickup", "Priority Mail Express 1-Day Hold For Pickup"},
+		{"USPS Retail Ground&lt;sup&gt;&#174;&lt;/sup&gt;", "USPS Retail Ground"},
+		{"First-Class Mail&#174; Large Envelope", "First-Class Mail Large Envelope"},
+		{"Priority Mail&lt;sup&gt;&#174;&lt;/sup&gt; Small Flat Rate&lt;sup&gt;&#174;&lt;/sup&gt; Box", "Priority Mail Small Flat Rate Box"},
+		{"Media Mail Parcel", "Media Mail Parcel"},
+	}
+
+	for _, tt := range tests {
+		if got := readableMailService(tt.in); got != tt.want {
+			t.Errorf("readableMailService(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestServiceForMailService(t *testing.T) {
+	tests := []struct {
+		name string
+		want Service
+	}{
+		{"Priority Mail Express 1-Day", ServiceExpress},
+		{"Priority Mail Express 1-Day Hold For Pickup", ServiceExpressHFP},
+		{"Priority Mail Express 1-Day Sunday/Holiday Delivery", ServiceExpressSH},
+		{"Priority Mail 2-Day", ServicePriority},
+		{"Priority Mail 3-Day Padded Flat Rate Envelope", ServicePriority},
+		{"First-Class Package Service - Retail", ServiceFirstClass},
+		{"USPS Retail Ground", ServiceParcel},
+		{"Standard Post", ServiceParcel},
+		{"Parcel Post", ServiceParcel},
+		{"Media Mail Parcel", ServiceMedia},
+		{"Library Mail Parcel", ServiceLibrary},
+		{"Bound Printed Matter", ""},
+	}
+
+	for _, tt := range tests {
+		if got := serviceForMailService(tt.name); got != tt.want {
+			t.Errorf("serviceForMailService(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
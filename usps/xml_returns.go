@@ -0,0 +1,45 @@
+package usps
+
+import (
+	"encoding/xml"
+)
+
+type USPSReturnsLabelRequest struct {
+	XMLName         xml.Name `xml:"USPSReturnsLabelRequest"`
+	UserId          string   `xml:"USERID,attr"`
+	Option          string
+	Revision        string
+	ImageParameters struct {
+		ImageType LabelImageType
+	}
+	CustomerFirstName          string
+	CustomerLastName           string
+	CustomerFirm               string
+	CustomerAddress1           string
+	CustomerAddress2           string
+	CustomerUrbanization       string
+	CustomerCity               string
+	CustomerState              string
+	CustomerZip5               string
+	CustomerZip4               string
+	POZipCode                  string
+	AllowNonCleansedOriginAddr bool
+	RetailerATTN               string
+	RetailerFirm               string
+	WeightInOunces             float64
+	ServiceType                string
+	Width                      float64 `xml:",omitempty"`
+	Length                     float64 `xml:",omitempty"`
+	Height                     float64 `xml:",omitempty"`
+	Girth                      float64 `xml:",omitempty"`
+	Machinable                 bool
+	CustomerRefNo              string
+	PrintCustomerRefNo         bool
+}
+
+type USPSReturnsLabelResponse struct {
+	XMLName        xml.Name `xml:"USPSReturnsLabelResponse"`
+	TrackingNumber string
+	ReturnLabel    string // Base64.
+	Postage        float64
+}
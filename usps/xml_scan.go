@@ -0,0 +1,38 @@
+package usps
+
+import (
+	"encoding/xml"
+)
+
+type SCANRequest struct {
+	XMLName xml.Name `xml:"SCANRequest"`
+	UserId  string   `xml:"USERID,attr"`
+	Option  struct {
+		Form string
+	}
+	FromName     string
+	FromFirm     string
+	FromAddress1 string
+	FromAddress2 string
+	FromCity     string
+	FromState    string
+	FromZip5     string
+	FromZip4     string
+	Shipment     struct {
+		PackageDetail []SCANPackageDetail
+	}
+	MailDate      string // YYYYMMDD
+	MailTime      string // HHMMSS
+	EntryFacility string
+	ImageType     LabelImageType
+}
+
+type SCANResponse struct {
+	XMLName        xml.Name `xml:"SCANResponse"`
+	SCANFormNumber string
+	SCANFormImage  string // Base64.
+}
+
+type SCANPackageDetail struct {
+	PkgBarcode string
+}
@@ -0,0 +1,42 @@
+package usps
+
+import (
+	"encoding/xml"
+)
+
+// PriorityMail, FirstClassMail and StandardB share one request and response
+// layout; only the root element names differ.
+type ServiceStandardRequest struct {
+	XMLName        xml.Name
+	UserId         string `xml:"USERID,attr"`
+	OriginZip      string
+	DestinationZip string
+}
+
+type ServiceStandardResponse struct {
+	OriginZip      string
+	DestinationZip string
+	Days           int
+	Message        string
+}
+
+type ExpressMailCommitmentRequest struct {
+	XMLName        xml.Name `xml:"ExpressMailCommitmentRequest"`
+	UserId         string   `xml:"USERID,attr"`
+	OriginZIP      string
+	DestinationZIP string
+	Date           string // DD-Mon-YYYY
+}
+
+type ExpressMailCommitmentResponse struct {
+	XMLName                 xml.Name `xml:"ExpressMailCommitmentResponse"`
+	OriginZIP               string
+	DestinationZIP          string
+	Date                    string
+	EffectiveAcceptanceDate string // YYYY-MM-DD
+	Commitment              []struct {
+		CommitmentName     string // e.g. "1-Day"
+		CommitmentTime     string // e.g. "3:00 PM"
+		CommitmentSequence string
+	}
+}
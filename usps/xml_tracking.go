@@ -0,0 +1,43 @@
+package usps
+
+import (
+	"encoding/xml"
+)
+
+type TrackFieldRequest struct {
+	XMLName  xml.Name `xml:"TrackFieldRequest"`
+	UserId   string   `xml:"USERID,attr"`
+	Revision string
+	ClientIp string
+	SourceId string
+	TrackIDs []RequestTrackID `xml:"TrackID"`
+}
+
+type RequestTrackID struct {
+	ID string `xml:",attr"`
+}
+
+type TrackResponse struct {
+	XMLName   xml.Name `xml:"TrackResponse"`
+	TrackInfo []struct {
+		ID           string `xml:",attr"`
+		TrackSummary *TrackDetail
+		TrackDetail  []TrackDetail
+		Error        *APIError
+	}
+}
+
+type TrackDetail struct {
+	EventTime             string
+	EventDate             string
+	Event                 string
+	EventCity             string
+	EventState            string
+	EventZIPCode          string
+	EventCountry          string
+	FirmName              string
+	Name                  string
+	AuthorizedAgent       string
+	EventCode             string
+	DeliveryAttributeCode string
+}
@@ -0,0 +1,133 @@
+package shipping
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/functionary/shipping/usps"
+)
+
+const rateV4Reply = `<?xml version="1.0" encoding="UTF-8"?>
+<RateV4Response><Package ID="0"><ZipOrigination>21093</ZipOrigination><ZipDestination>30328</ZipDestination><Pounds>0</Pounds><Ounces>10</Ounces><Container>VARIABLE</Container><Zone>5</Zone>` +
+	`<Postage CLASSID="1"><MailService>Priority Mail 2-Day&amp;lt;sup&amp;gt;&amp;#8482;&amp;lt;/sup&amp;gt;</MailService><Rate>9.45</Rate></Postage>` +
+	`<Postage CLASSID="0"><MailService>First-Class Package Service - Retail&amp;lt;sup&amp;gt;&amp;#8482;&amp;lt;/sup&amp;gt;</MailService><Rate>5.20</Rate></Postage>` +
+	`</Package></RateV4Response>`
+
+func testUSPSRater(t *testing.T) (*USPSRater, *[]string) {
+	t.Helper()
+
+	baseURL, bodies := testServer(t, rateV4Reply)
+	client := usps.NewClient("TESTUSER")
+	client.BaseURL = baseURL
+	return &USPSRater{Client: client}, bodies
+}
+
+var (
+	testFrom = Address{PostalCode: "21093-1234"}
+	testTo   = Address{PostalCode: "303281001"}
+)
+
+func TestUSPSRaterRate(t *testing.T) {
+	r, bodies := testUSPSRater(t)
+
+	e, err := r.Rate(context.Background(), string(usps.ServicePriority), testFrom, testTo, Package{Weight: 10, Width: 14, Height: 4, Length: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Estimate{Name: "Priority Mail 2-Day", Provider: USPS, Service: string(usps.ServicePriority), Price: 9.45}
+	if e != want {
+		t.Errorf("got %+v, want %+v", e, want)
+	}
+
+	body := (*bodies)[0]
+	for _, s := range []string{
+		"<ZipOrigination>21093</ZipOrigination>",
+		"<ZipDestination>30328</ZipDestination>",
+		"<Container>RECTANGULAR</Container>",
+		"<Size>LARGE</Size>",
+	} {
+		if !strings.Contains(body, s) {
+			t.Errorf("request is missing %s:\n%s", s, body)
+		}
+	}
+	if strings.Contains(body, "FirstClassMailType") {
+		t.Errorf("FirstClassMailType sent for Priority:\n%s", body)
+	}
+}
+
+func TestUSPSRaterRateFirstClass(t *testing.T) {
+	r, bodies := testUSPSRater(t)
+
+	e, err := r.Rate(context.Background(), string(usps.ServiceFirstClass), testFrom, testTo, Package{Weight: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Price != 5.20 || e.Service != string(usps.ServiceFirstClass) {
+		t.Errorf("got %+v, want the First-Class estimate", e)
+	}
+
+	body := (*bodies)[0]
+	for _, s := range []string{
+		"<FirstClassMailType>PARCEL</FirstClassMailType>",
+		"<Container>VARIABLE</Container>",
+		"<Size>REGULAR</Size>",
+	} {
+		if !strings.Contains(body, s) {
+			t.Errorf("request is missing %s:\n%s", s, body)
+		}
+	}
+}
+
+func TestUSPSRaterShop(t *testing.T) {
+	r, _ := testUSPSRater(t)
+
+	estimates, err := r.Shop(context.Background(), testFrom, testTo, Package{Weight: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Estimate{
+		{Name: "Priority Mail 2-Day", Provider: USPS, Service: string(usps.ServicePriority), Price: 9.45},
+		{Name: "First-Class Package Service - Retail", Provider: USPS, Service: string(usps.ServiceFirstClass), Price: 5.20},
+	}
+	if len(estimates) != len(want) {
+		t.Fatalf("got %d estimates, want %d", len(estimates), len(want))
+	}
+	for i := range want {
+		if estimates[i] != want[i] {
+			t.Errorf("estimate %d = %+v, want %+v", i, estimates[i], want[i])
+		}
+	}
+}
+
+func TestUSPSRaterInternational(t *testing.T) {
+	r, bodies := testUSPSRater(t)
+
+	_, err := r.Shop(context.Background(), testFrom, Address{PostalCode: "SW1A 1AA", Country: "GB"}, Package{Weight: 10})
+	if err == nil {
+		t.Error("got no error for an international address")
+	}
+	if len(*bodies) != 0 {
+		t.Errorf("sent %d requests, want none", len(*bodies))
+	}
+}
+
+func TestZip5(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"21093", "21093"},
+		{"21093-1234", "21093"},
+		{"210931234", "21093"},
+		{" 21093 ", "21093"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := zip5(tt.in); got != tt.want {
+			t.Errorf("zip5(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}